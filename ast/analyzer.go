@@ -0,0 +1,616 @@
+package ast
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/commentcov/commentcov/proto"
+)
+
+// Options holds the settings of the analyses performed beside the comment coverage.
+type Options struct {
+	// IgnoreParamTypes are the parameter types excluded from the parameter documentation coverage.
+	IgnoreParamTypes []string
+	// Examples enables scanning the test files for the example functions documenting the identifiers.
+	Examples bool
+	// SpellCheck enables the spell checking of the header comments.
+	SpellCheck bool
+	// SpellDictionary is the path to the project dictionary file used by the spell checking in addition to the built-in one.
+	SpellDictionary string
+	// Language is the label of the language the header comments are required to be written in, like "en".
+	// The check is disabled if empty.
+	Language string
+	// Attribution is how the comments are attributed to the declarations, either AttributionSpec or AttributionGroup.
+	Attribution string
+	// ExcludeGenerated excludes the generated files, which have the "Code generated ... DO NOT EDIT." comment.
+	ExcludeGenerated bool
+	// DisabledChecks are the names of the checks whose Diagnostics are dropped.
+	DisabledChecks map[string]bool
+	// MinCommentWords is the minimum number of the words of the header comments. The check is disabled if 0.
+	MinCommentWords int
+	// MinParamRatio is the minimum parameter documentation coverage of the functions. The check is disabled if 0.
+	MinParamRatio float64
+	// InternalPrivate maps the exported identifiers and the package comments of the internal packages to the private scopes.
+	InternalPrivate bool
+	// CommandPrivate maps the identifiers of the main packages to the private scopes,
+	// leaving the package comments as the usage documentation of the commands.
+	CommandPrivate bool
+	// Reachability defines the public scopes by the reachability from the exported API of the package,
+	// instead of the capitalization of each identifier.
+	Reachability bool
+	// TestMode is how the test files are measured, either TestModeMeasure, TestModeExempt or TestModeSeparate.
+	TestMode string
+	// QualifiedIdentifiers qualifies the identifiers of the CoverageItems with the import paths of their packages.
+	QualifiedIdentifiers bool
+	// Workspace honors the `use` directives of go.work, excluding the files of the modules not in the workspace.
+	Workspace bool
+	// ThirdPartyRoots are the directories of the third-party code, like third_party/.
+	// The vendor directories and the module cache are recognized without them.
+	ThirdPartyRoots []string
+	// ThirdPartyMode is how the files not of OriginFirstParty are handled, either ThirdPartySkip or ThirdPartyLabel.
+	ThirdPartyMode string
+	// Kinds enables or disables the kinds of the CoverageItems by name, like KindField and KindPrivate.
+	// The kinds not listed are measured except KindField.
+	Kinds map[string]bool
+	// Rule is the name of the policy rule which the Options are configured by, recorded in the Details. It is empty if none.
+	Rule string
+}
+
+// The modes of the attribution of the comments.
+const (
+	// AttributionSpec attributes the comments only to the declaration or the spec right below them.
+	AttributionSpec = "spec"
+	// AttributionGroup also attributes the doc comment of a grouped declaration, like `const ( ... )`,
+	// to each spec in the group which has no header comments of its own.
+	AttributionGroup = "group"
+)
+
+// Checks are the names of all the checks.
+var Checks = []string{
+	DocLinkCheck,
+	StaleCommentCheck,
+	SpellCheck,
+	CommentedOutCodeCheck,
+	DocFormatCheck,
+	ErrorDocCheck,
+	PanicDocCheck,
+	ConcurrencyDocCheck,
+	LanguageCheck,
+	SecretCheck,
+	CommentLengthCheck,
+	ParamDocCheck,
+	IgnoreCheck,
+}
+
+// DefaultOptions returns the Options used when nothing is configured.
+func DefaultOptions() *Options {
+	return &Options{
+		IgnoreParamTypes: []string{
+			"context.Context",
+		},
+		Attribution:     AttributionSpec,
+		DisabledChecks:  map[string]bool{},
+		InternalPrivate: true,
+		CommandPrivate:  true,
+		TestMode:        TestModeMeasure,
+		Workspace:       true,
+		ThirdPartyMode:  ThirdPartySkip,
+		Kinds:           map[string]bool{},
+	}
+}
+
+// Detail holds the analysis results of a CoverageItem which proto.CoverageItem has no field for.
+type Detail struct {
+	// Params is the parameter documentation coverage. It is set only for functions.
+	Params *ParamCoverage
+	// Examples are the names of the example functions documenting the identifier.
+	// It is nil unless Options.Examples is enabled.
+	Examples []string
+	// Metrics is the structural metrics of the HeaderComments.
+	Metrics *Metrics
+	// Language is the label of the dominant language of the HeaderComments detected by DetectLanguage.
+	Language string
+	// Kind is the kind of the CoverageItem, like KindFunction.
+	Kind string
+	// Rule is the name of the policy rule applied to the CoverageItem. It is empty if none.
+	Rule string
+	// Category is the category of the CoverageItem, which tells the test code from the production code.
+	Category string
+	// Module is the path of the module owning the CoverageItem. It is empty if the file is in no module.
+	Module string
+}
+
+// Result is the outcome of analyzing a file.
+type Result struct {
+	Package     *Package
+	Items       []*proto.CoverageItem
+	Details     map[*proto.CoverageItem]*Detail
+	Diagnostics []*Diagnostic
+	// Ignored are the CoverageItems excluded by the ignore directives and the test mode. They are not in Items.
+	Ignored []*Ignored
+	// Separated are the CoverageItems of the test files scored separately by the test mode. They are not in Items,
+	// but their Details are.
+	Separated []*proto.CoverageItem
+	// Origin is the origin of the file, like OriginFirstParty and OriginVendor.
+	Origin string
+	// ThirdParty are the CoverageItems of the file not of OriginFirstParty labeled by ThirdPartyLabel.
+	// They are not in Items, but their Details are.
+	ThirdParty []*proto.CoverageItem
+}
+
+// label labels the Result with the origin of the file.
+// The CoverageItems of the third-party code are moved to ThirdParty, and their Diagnostics are dropped.
+func (r *Result) label(origin string) {
+	r.Origin = origin
+	if origin == OriginFirstParty {
+		return
+	}
+
+	r.ThirdParty = append(r.ThirdParty, r.Items...)
+	r.Items = []*proto.CoverageItem{}
+	r.Diagnostics = []*Diagnostic{}
+}
+
+// exemptReason is the reason of the test entry points exempted by TestModeExempt.
+const exemptReason = "test entry point"
+
+// ignore returns true if the CoverageItem is excluded by the ignore directives or the test mode, appending it to Ignored.
+func (r *Result) ignore(ig *Ignores, ci *proto.CoverageItem, exempt bool) bool {
+	reason, ok := ig.Of(ci)
+	if !ok && exempt {
+		reason, ok = exemptReason, true
+	}
+
+	if ok {
+		r.Ignored = append(r.Ignored, &Ignored{Item: ci, Reason: reason})
+	}
+
+	return ok
+}
+
+// add appends the CoverageItem with its Detail to the Result.
+// The Detail common to all the CoverageItems is filled here.
+func (r *Result) add(ci *proto.CoverageItem, d *Detail) {
+	d.Metrics = ProcessMetrics(ci.HeaderComments)
+	d.Language = DetectLanguage(commentsText(ci.HeaderComments))
+	if r.Package.Module != nil {
+		d.Module = r.Package.Module.Path
+	}
+
+	r.Items = append(r.Items, ci)
+	r.Details[ci] = d
+}
+
+// Analyzer measures the comment coverage of files along with the plugin side analyses.
+// It caches the packages loaded for the files, so the same Analyzer should be used across a batch of files.
+type Analyzer struct {
+	options    *Options
+	fset       *token.FileSet
+	packages   map[string]*Package
+	modules    map[string]*Module
+	workspaces map[string]*Workspace
+	dictionary Dictionary
+}
+
+// NewAnalyzer returns a new Analyzer.
+func NewAnalyzer(options *Options) *Analyzer {
+	return &Analyzer{
+		options:    options,
+		fset:       token.NewFileSet(),
+		packages:   map[string]*Package{},
+		modules:    map[string]*Module{},
+		workspaces: map[string]*Workspace{},
+	}
+}
+
+// AnalyzeFile parses the given file and analyzes it.
+// It returns nil Result if the file is excluded by the Options.
+func (a *Analyzer) AnalyzeFile(file string) (*Result, error) {
+	if err := a.LoadDictionary(); err != nil {
+		return nil, err
+	}
+
+	origin := OriginOf(file, a.options.ThirdPartyRoots)
+	if origin != OriginFirstParty && a.options.ThirdPartyMode == ThirdPartySkip {
+		return nil, nil
+	}
+
+	m, err := a.moduleOf(filepath.Dir(file))
+	if err != nil {
+		return nil, err
+	}
+
+	if a.options.Workspace {
+		ok, err := a.inWorkspace(file, m)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			return nil, nil
+		}
+	}
+
+	f, err := parser.ParseFile(a.fset, file, nil, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.options.ExcludeGenerated && ast.IsGenerated(f) {
+		return nil, nil
+	}
+
+	pkg, err := a.packageOf(file, f, m)
+	if err != nil {
+		return nil, err
+	}
+
+	res := a.Analyze(file, a.fset, f, pkg)
+	res.label(origin)
+
+	return res, nil
+}
+
+// LoadDictionary loads the dictionaries for the spell checking if enabled.
+// It is called by AnalyzeFile, and has to be called before Analyze.
+func (a *Analyzer) LoadDictionary() error {
+	if !a.options.SpellCheck || a.dictionary != nil {
+		return nil
+	}
+
+	d := BuiltinDictionary()
+	if a.options.SpellDictionary != "" {
+		if err := d.LoadDictionary(a.options.SpellDictionary); err != nil {
+			return fmt.Errorf("failed to load the spell dictionary: %w", err)
+		}
+	}
+
+	a.dictionary = d
+	return nil
+}
+
+// packageOf returns the Package which the given file of the Module belongs to.
+// The Module is nil if the file is not in any module.
+func (a *Analyzer) packageOf(file string, f *ast.File, m *Module) (*Package, error) {
+	dir := filepath.Dir(file)
+	key := dir + string(filepath.ListSeparator) + f.Name.Name
+
+	pkg, ok := a.packages[key]
+	if !ok {
+		var err error
+		pkg, err = LoadPackage(a.fset, dir, f.Name.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		if a.options.Examples {
+			pkg.Examples, err = LoadExamples(a.fset, dir, f.Name.Name)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if m != nil {
+			pkg.Module = m
+			pkg.ImportPath = m.ImportPath(dir)
+			if pkg.ImportPath != "" && pkg.IsExternalTest() {
+				pkg.ImportPath += externalTestSuffix
+			}
+		}
+
+		a.packages[key] = pkg
+	}
+
+	// test files are not loaded as a part of the package, except the external test package.
+	if IsTestFile(file) && !pkg.IsExternalTest() {
+		p := NewPackage(pkg.Name, append(slices.Clone(pkg.Files), f)...)
+		p.Dir = pkg.Dir
+		p.Internal = pkg.Internal
+		p.Module = pkg.Module
+		p.ImportPath = pkg.ImportPath
+		p.Examples = pkg.Examples
+		return p, nil
+	}
+
+	return pkg, nil
+}
+
+// moduleOf returns the Module of the nearest go.mod of the directory, caching it.
+// It returns nil if there is no go.mod.
+func (a *Analyzer) moduleOf(dir string) (*Module, error) {
+	root := ModuleRoot(dir)
+	if root == "" {
+		return nil, nil
+	}
+
+	m, ok := a.modules[root]
+	if !ok {
+		var err error
+		m, err = LoadModule(root)
+		if err != nil {
+			return nil, err
+		}
+
+		a.modules[root] = m
+	}
+
+	return m, nil
+}
+
+// inWorkspace returns true if the given file of the Module belongs to the workspace of the nearest go.work.
+// The files are regarded as in the workspace if there is no go.work or they are not in any module,
+// and the nested modules not in the `use` directives are excluded as the go command does.
+func (a *Analyzer) inWorkspace(file string, m *Module) (bool, error) {
+	if m == nil {
+		return true, nil
+	}
+
+	wf := WorkspaceFile(filepath.Dir(file))
+	if wf == "" {
+		return true, nil
+	}
+
+	w, ok := a.workspaces[wf]
+	if !ok {
+		var err error
+		w, err = LoadWorkspace(wf)
+		if err != nil {
+			return false, err
+		}
+
+		a.workspaces[wf] = w
+	}
+
+	return w.Uses(m.Dir), nil
+}
+
+// Analyze analyzes the given parsed file which belongs to pkg.
+func (a *Analyzer) Analyze(file string, fset *token.FileSet, f *ast.File, pkg *Package) *Result {
+	res := &Result{
+		Package:     pkg,
+		Items:       []*proto.CoverageItem{},
+		Details:     map[*proto.CoverageItem]*Detail{},
+		Diagnostics: []*Diagnostic{},
+		Ignored:     []*Ignored{},
+		Separated:   []*proto.CoverageItem{},
+		Origin:      OriginFirstParty,
+	}
+
+	ig := NewIgnores(file, fset, f)
+	res.Diagnostics = append(res.Diagnostics, ig.Diagnostics...)
+
+	ci := ProcessPackageCoverage(file, fset, f)
+	a.keepCommentedOutCode(fset, f, ci)
+	a.qualify(pkg, ci, "", "")
+	a.applyVisibility(pkg, ci, "")
+	if category := CategoryOf(file, nil); a.options.isMeasured(KindPackage, ci) && !res.ignore(ig, ci, a.isExempt(category)) {
+		res.add(ci, &Detail{
+			Examples: pkg.examplesOf(""),
+			Category: category,
+			Kind:     KindPackage,
+			Rule:     a.options.Rule,
+		})
+		res.Diagnostics = append(res.Diagnostics, CheckStaleComment(file, fset, f, pkg, ci, []string{f.Name.Name})...)
+	}
+
+	for _, decl := range f.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			ci := ProcessFunctionCoverage(file, fset, f, d)
+			a.keepCommentedOutCode(fset, f, ci)
+			a.qualify(pkg, ci, ReceiverTypeName(d), d.Name.Name)
+			a.applyVisibility(pkg, ci, ReachabilityKey(ReceiverTypeName(d), d.Name.Name))
+			kind := KindFunction
+			if d.Recv != nil {
+				kind = KindMethod
+			}
+
+			category := CategoryOf(file, d)
+			if !a.options.isMeasured(kind, ci) || res.ignore(ig, ci, a.isExempt(category)) {
+				continue
+			}
+
+			pc := ProcessParamCoverage(d, ci.HeaderComments, a.options.IgnoreParamTypes)
+			res.add(ci, &Detail{
+				Params:   pc,
+				Examples: pkg.examplesOf(ExampleKey(d)),
+				Category: category,
+				Kind:     kind,
+				Rule:     a.options.Rule,
+			})
+			res.Diagnostics = append(res.Diagnostics, CheckParamDoc(file, fset, d, ci, pc, a.options.MinParamRatio)...)
+			res.Diagnostics = append(res.Diagnostics, CheckStaleComment(file, fset, f, pkg, ci, []string{d.Name.Name})...)
+			res.Diagnostics = append(res.Diagnostics, CheckErrorDoc(file, fset, d, ci)...)
+			res.Diagnostics = append(res.Diagnostics, CheckPanicDoc(file, fset, d, ci)...)
+
+		case *ast.GenDecl:
+			names := SpecNames(d)
+			specs := TypeSpecs(d)
+			cis := ProcessGenDeclCoverage(file, fset, f, d)
+			for _, ci := range cis {
+				a.keepCommentedOutCode(fset, f, ci)
+			}
+			if a.options.Attribution == AttributionGroup {
+				AttributeGroupDoc(fset, d, cis)
+			}
+
+			category := CategoryOf(file, d)
+			scopes := map[string]proto.CoverageItem_Scope{}
+			for _, ci := range cis {
+				name := ci.Identifier
+				a.qualify(pkg, ci, "", name)
+				a.applyVisibility(pkg, ci, name)
+				scopes[name] = ci.Scope
+				if !a.options.isMeasured(kindOf(ci), ci) || res.ignore(ig, ci, a.isExempt(category)) {
+					continue
+				}
+
+				res.add(ci, &Detail{
+					Examples: pkg.examplesOf(name),
+					Category: category,
+					Kind:     kindOf(ci),
+					Rule:     a.options.Rule,
+				})
+				res.Diagnostics = append(res.Diagnostics, CheckStaleComment(file, fset, f, pkg, ci, names[name])...)
+
+				if spec, ok := specs[name]; ok {
+					res.Diagnostics = append(res.Diagnostics, CheckConcurrencyDoc(file, fset, f, spec, ci)...)
+				}
+			}
+
+			if a.options.IsKindEnabled(KindField) {
+				a.analyzeFields(file, fset, f, pkg, d, scopes, res, ig)
+			}
+		}
+	}
+
+	res.Diagnostics = append(res.Diagnostics, CheckCommentedOutCode(file, fset, f, res.Items)...)
+	secrets := CommentSecrets(f)
+	res.Diagnostics = append(res.Diagnostics, CheckSecrets(file, fset, f, res.Items, secrets)...)
+
+	var identifiers Dictionary
+	if a.dictionary != nil {
+		identifiers = FileIdentifiers(f)
+	}
+
+	for _, ci := range res.Items {
+		res.Diagnostics = append(res.Diagnostics, CheckDocLinks(file, fset, f, pkg, ci)...)
+		res.Diagnostics = append(res.Diagnostics, CheckDocFormat(file, fset, f, ci)...)
+		res.Diagnostics = append(res.Diagnostics, CheckCommentLength(file, ci, res.Details[ci].Metrics, a.options.MinCommentWords)...)
+
+		if a.dictionary != nil {
+			res.Diagnostics = append(res.Diagnostics, CheckSpelling(file, fset, f, ci, a.dictionary, identifiers)...)
+		}
+
+		if a.options.Language != "" {
+			res.Diagnostics = append(res.Diagnostics, CheckLanguage(file, fset, f, ci, a.options.Language)...)
+		}
+	}
+
+	res.Diagnostics = slices.DeleteFunc(res.Diagnostics, func(d *Diagnostic) bool {
+		return a.options.DisabledChecks[d.Check]
+	})
+
+	// the other checks may quote the comments, which must not reveal the secrets.
+	found := []*Secret{}
+	for _, ss := range secrets {
+		found = append(found, ss...)
+	}
+	for _, d := range res.Diagnostics {
+		d.Message = RedactSecrets(d.Message, found)
+		d.Suggestion = RedactSecrets(d.Suggestion, found)
+	}
+
+	if a.options.TestMode == TestModeSeparate {
+		res.Items = slices.DeleteFunc(res.Items, func(ci *proto.CoverageItem) bool {
+			if res.Details[ci].Category == CategoryProduction {
+				return false
+			}
+
+			res.Separated = append(res.Separated, ci)
+			return true
+		})
+	}
+
+	return res
+}
+
+// analyzeFields measures the fields of the struct types declared by the GenDecl.
+// scopes are the scopes of the types by name, and the fields of the private types are private regardless of their names.
+func (a *Analyzer) analyzeFields(
+	file string, fset *token.FileSet, f *ast.File, pkg *Package, gdecl *ast.GenDecl, scopes map[string]proto.CoverageItem_Scope,
+	res *Result, ig *Ignores,
+) {
+	category := CategoryOf(file, gdecl)
+	for _, s := range gdecl.Specs {
+		ts, ok := s.(*ast.TypeSpec)
+		if !ok {
+			continue
+		}
+
+		for _, ci := range ProcessFieldCoverage(file, fset, f, ts) {
+			a.keepCommentedOutCode(fset, f, ci)
+			name := strings.TrimPrefix(ci.Identifier, ts.Name.Name+".")
+			a.qualify(pkg, ci, ts.Name.Name, name)
+			if IsPrivateScope(scopes[ts.Name.Name]) {
+				ci.Scope = PrivateScope(ci.Scope)
+			}
+
+			if !a.options.isMeasured(KindField, ci) || res.ignore(ig, ci, a.isExempt(category)) {
+				continue
+			}
+
+			res.add(ci, &Detail{
+				Category: category,
+				Kind:     KindField,
+				Rule:     a.options.Rule,
+			})
+			res.Diagnostics = append(res.Diagnostics, CheckStaleComment(file, fset, f, pkg, ci, []string{name})...)
+		}
+	}
+}
+
+// keepCommentedOutCode adds the commented-out code back to the comments of the CoverageItem
+// if the commented_out_code check is disabled, so that the check does not affect the coverage then.
+func (a *Analyzer) keepCommentedOutCode(fset *token.FileSet, f *ast.File, ci *proto.CoverageItem) {
+	if !a.options.DisabledChecks[CommentedOutCodeCheck] {
+		return
+	}
+
+	ci.HeaderComments = withCommentedOutCode(fset, f, ci.TargetBlock, ci.HeaderComments, IsHeader)
+	ci.InlineComments = withCommentedOutCode(fset, f, ci.TargetBlock, ci.InlineComments, IsInline)
+}
+
+// qualify qualifies the identifier of the CoverageItem with the import path of the package if enabled.
+// It is called before the checks, so that their Diagnostics carry the qualified identifiers.
+func (a *Analyzer) qualify(pkg *Package, ci *proto.CoverageItem, recv, name string) {
+	if a.options.QualifiedIdentifiers && pkg.ImportPath != "" {
+		ci.Identifier = pkg.Qualify(recv, name)
+	}
+}
+
+// isExempt returns true if the CoverageItems of the category are exempted by the test mode.
+func (a *Analyzer) isExempt(category string) bool {
+	return a.options.TestMode == TestModeExempt && category == CategoryTest
+}
+
+// applyVisibility rewrites the scope of the CoverageItem by the visibility policies beyond the capitalization.
+// key is the ReachabilityKey of the CoverageItem, which is "" for the package comment.
+func (a *Analyzer) applyVisibility(pkg *Package, ci *proto.CoverageItem, key string) {
+	switch {
+	case a.options.CommandPrivate && pkg.IsCommand():
+		ci.Scope = CommandScope(ci.Scope)
+	case a.options.InternalPrivate && pkg.Internal:
+		ci.Scope = PrivateScope(ci.Scope)
+	case a.options.Reachability && key != "":
+		if pkg.reachableKey(key) {
+			ci.Scope = PublicScope(ci.Scope)
+		} else {
+			ci.Scope = PrivateScope(ci.Scope)
+		}
+	}
+}
+
+// AttributeGroupDoc attributes the doc comment of the grouped declaration to the CoverageItems of its specs
+// which have no HeaderComments.
+func AttributeGroupDoc(fset *token.FileSet, gdecl *ast.GenDecl, items []*proto.CoverageItem) {
+	if !gdecl.Lparen.IsValid() || gdecl.Doc == nil || !IsDocumentation(gdecl.Doc.Text()) {
+		return
+	}
+
+	for _, ci := range items {
+		if len(ci.HeaderComments) == 0 {
+			ci.HeaderComments = []*proto.Comment{
+				{
+					Comment: NormalizeGroup(gdecl.Doc),
+					Block:   NewBlock(fset, gdecl.Doc.Pos(), gdecl.Doc.End()),
+				},
+			}
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package ast_test
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/commentcov/commentcov/proto"
+	"github.com/google/go-cmp/cmp"
+
+	myAst "github.com/commentcov/commentcov-plugin-go/ast"
+)
+
+// TestAnalyze is the unittest for Analyzer.Analyze.
+func TestAnalyze(t *testing.T) {
+	src := `// hoge Header
+package hoge
+
+// MyVar Header
+var MyVar string
+
+// MyFunc uses a.
+func MyFunc(ctx context.Context, a, b string) {}
+`
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "hoge.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := myAst.NewAnalyzer(myAst.DefaultOptions()).Analyze("hoge.go", fset, f, myAst.NewPackage("hoge", f))
+
+	want := myAst.ProcessFileCoverage("hoge.go", fset, f)
+	if diff := cmp.Diff(want, res.Items, coverageItemCmp); diff != "" {
+		t.Errorf("CoverageItem values are mismatch (-want +got):%s\n", diff)
+	}
+
+	wantParams := map[proto.CoverageItem_Scope]*myAst.ParamCoverage{
+		proto.CoverageItem_FILE:            nil,
+		proto.CoverageItem_PUBLIC_VARIABLE: nil,
+		proto.CoverageItem_PUBLIC_FUNCTION: {
+			Mentioned: []string{"a"},
+			Missing:   []string{"b"},
+		},
+	}
+	for _, ci := range res.Items {
+		got := res.Details[ci].Params
+		if diff := cmp.Diff(wantParams[ci.Scope], got); diff != "" {
+			t.Errorf("ParamCoverage values of %s are mismatch (-want +got):%s\n", ci.Identifier, diff)
+		}
+	}
+}
+
+// TestAnalyzeFile_Examples is the unittest for Analyzer.AnalyzeFile with Options.Examples.
+func TestAnalyzeFile_Examples(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"hoge.go": `package hoge
+
+type Bar struct{}
+
+func (b *Bar) Method() {}
+
+func Foo() {}
+
+var Baz string
+`,
+		"hoge_test.go": "package hoge_test\n\nfunc Example() {}\n\nfunc ExampleFoo() {}\n\nfunc ExampleBar_Method() {}\n",
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	options := myAst.DefaultOptions()
+	options.Examples = true
+
+	res, err := myAst.NewAnalyzer(options).AnalyzeFile(filepath.Join(dir, "hoge.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string][]string{
+		"hoge":   {"Example"},
+		"Bar":    {},
+		"Method": {"ExampleBar_Method"},
+		"Foo":    {"ExampleFoo"},
+		"Baz":    {},
+	}
+	got := map[string][]string{}
+	for _, ci := range res.Items {
+		got[ci.Identifier] = res.Details[ci].Examples
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("example values are mismatch (-want +got):%s\n", diff)
+	}
+}
+
+// TestAnalyze_Options is the unittest for Analyzer.Analyze with the Options configured.
+//
+//nolint:funlen
+func TestAnalyze_Options(t *testing.T) {
+	src := `package hoge
+
+// Grouped constants.
+const (
+	A = 1
+
+	// B is documented.
+	B = 2
+)
+
+// Foo does.
+func Foo(a, b string) {}
+`
+
+	tests := []struct {
+		name            string
+		configure       func(o *myAst.Options)
+		wantHeaders     map[string]string
+		wantDiagnostics []string
+	}{
+		{
+			name:      "default",
+			configure: func(_ *myAst.Options) {},
+			wantHeaders: map[string]string{
+				"hoge": "",
+				"A":    "",
+				"B":    "B is documented.\n",
+				"Foo":  "Foo does.\n",
+			},
+			wantDiagnostics: []string{},
+		},
+		{
+			name: "group attribution",
+			configure: func(o *myAst.Options) {
+				o.Attribution = myAst.AttributionGroup
+			},
+			wantHeaders: map[string]string{
+				"hoge": "",
+				"A":    "Grouped constants.\n",
+				"B":    "B is documented.\n",
+				"Foo":  "Foo does.\n",
+			},
+			wantDiagnostics: []string{},
+		},
+		{
+			name: "thresholds",
+			configure: func(o *myAst.Options) {
+				o.MinCommentWords = 3
+				o.MinParamRatio = 0.5
+			},
+			wantHeaders: map[string]string{
+				"hoge": "",
+				"A":    "",
+				"B":    "B is documented.\n",
+				"Foo":  "Foo does.\n",
+			},
+			wantDiagnostics: []string{
+				"comment mentions 0 of 2 parameters, missing a, b",
+				"comment has 2 words, fewer than 3",
+			},
+		},
+		{
+			name: "disabled checks",
+			configure: func(o *myAst.Options) {
+				o.MinCommentWords = 3
+				o.MinParamRatio = 0.5
+				o.DisabledChecks[myAst.ParamDocCheck] = true
+			},
+			wantHeaders: map[string]string{
+				"hoge": "",
+				"A":    "",
+				"B":    "B is documented.\n",
+				"Foo":  "Foo does.\n",
+			},
+			wantDiagnostics: []string{
+				"comment has 2 words, fewer than 3",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			f, err := parser.ParseFile(fset, "hoge.go", src, parser.ParseComments)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			options := myAst.DefaultOptions()
+			tt.configure(options)
+			res := myAst.NewAnalyzer(options).Analyze("hoge.go", fset, f, myAst.NewPackage("hoge", f))
+
+			gotHeaders := map[string]string{}
+			for _, ci := range res.Items {
+				gotHeaders[ci.Identifier] = ""
+				for _, hc := range ci.HeaderComments {
+					gotHeaders[ci.Identifier] += hc.Comment
+				}
+			}
+			if diff := cmp.Diff(tt.wantHeaders, gotHeaders); diff != "" {
+				t.Errorf("HeaderComments values are mismatch (-want +got):%s\n", diff)
+			}
+
+			gotDiagnostics := []string{}
+			for _, d := range res.Diagnostics {
+				gotDiagnostics = append(gotDiagnostics, d.Message)
+			}
+			if diff := cmp.Diff(tt.wantDiagnostics, gotDiagnostics); diff != "" {
+				t.Errorf("Diagnostic messages are mismatch (-want +got):%s\n", diff)
+			}
+		})
+	}
+}
+
+// TestAnalyzeFile_ExcludeGenerated is the unittest for Analyzer.AnalyzeFile with Options.ExcludeGenerated.
+func TestAnalyzeFile_ExcludeGenerated(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "hoge.pb.go")
+	src := "// Code generated by protoc-gen-go. DO NOT EDIT.\n\npackage hoge\n\nfunc Foo() {}\n"
+	if err := os.WriteFile(file, []byte(src), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	options := myAst.DefaultOptions()
+	options.ExcludeGenerated = true
+
+	res, err := myAst.NewAnalyzer(options).AnalyzeFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res != nil {
+		t.Errorf("want nil, got %d items\n", len(res.Items))
+	}
+}
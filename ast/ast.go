@@ -2,7 +2,6 @@ package ast
 
 import (
 	"go/ast"
-	"go/parser"
 	"go/token"
 	"math"
 	"path/filepath"
@@ -25,20 +24,25 @@ func safeIntToUint32(n int) uint32 {
 	return uint32(n)
 }
 
-// FileToCoverageItems is the logic of the plugin.
-// it converts file to CoverageItems.
+// FileToCoverageItems converts file to CoverageItems by analyzing it with an Analyzer of DefaultOptions.
+// The plugin itself uses Analyzer directly, so that the Options and the Diagnostics are available.
 func FileToCoverageItems(_ hclog.Logger, file string) ([]*proto.CoverageItem, error) {
-	fset := token.NewFileSet()
-	f, err := parser.ParseFile(fset, file, nil, parser.ParseComments)
+	res, err := NewAnalyzer(DefaultOptions()).AnalyzeFile(file)
 	if err != nil {
 		return []*proto.CoverageItem{}, err
 	}
 
-	items := ProcessFileCoverage(file, fset, f)
-	return items, nil
+	if res == nil {
+		return []*proto.CoverageItem{}, nil
+	}
+
+	return res.Items, nil
 }
 
 // ProcessFileCoverage measures the comment coverage for the entire given file.
+// The declarations excluded by the ignore directives are omitted.
+// It is the low-level API without the package context, so unlike Analyzer.Analyze,
+// neither the visibility scopes, the Options nor the test mode are applied.
 func ProcessFileCoverage(file string, fset *token.FileSet, f *ast.File) []*proto.CoverageItem {
 	ci := ProcessPackageCoverage(file, fset, f)
 	items := []*proto.CoverageItem{
@@ -52,29 +56,35 @@ func ProcessFileCoverage(file string, fset *token.FileSet, f *ast.File) []*proto
 			items = append(items, ci)
 
 		case *ast.GenDecl:
-			switch d.Tok {
-			case token.IMPORT:
-				// not check the coverage when token.IMPORT
+			cis := ProcessGenDeclCoverage(file, fset, f, d)
+			items = append(items, cis...)
+		}
+	}
+
+	items, _ = NewIgnores(file, fset, f).Filter(items)
+	return items
+}
 
-			case token.CONST:
-				cis := ProcessVariableCoverage(file, fset, f, d)
-				items = append(items, cis...)
+// ProcessGenDeclCoverage measures the comment coverage of the given *ast.GenDecl according to its token.
+func ProcessGenDeclCoverage(file string, fset *token.FileSet, f *ast.File, gdecl *ast.GenDecl) []*proto.CoverageItem {
+	switch gdecl.Tok {
+	case token.IMPORT:
+		// not check the coverage when token.IMPORT
 
-			case token.VAR:
-				cis := ProcessVariableCoverage(file, fset, f, d)
-				items = append(items, cis...)
+	case token.CONST:
+		return ProcessVariableCoverage(file, fset, f, gdecl)
 
-			case token.TYPE:
-				cis := ProcessTypeCoverage(file, fset, f, d)
-				items = append(items, cis...)
+	case token.VAR:
+		return ProcessVariableCoverage(file, fset, f, gdecl)
 
-			case token.ADD, token.ADD_ASSIGN, token.AND, token.AND_ASSIGN, token.AND_NOT, token.AND_NOT_ASSIGN, token.ARROW, token.ASSIGN, token.BREAK, token.CASE, token.CHAN, token.CHAR, token.COLON, token.COMMA, token.COMMENT, token.CONTINUE, token.DEC, token.DEFAULT, token.DEFER, token.DEFINE, token.ELLIPSIS, token.ELSE, token.EOF, token.EQL, token.FALLTHROUGH, token.FLOAT, token.FOR, token.FUNC, token.GEQ, token.GO, token.GOTO, token.GTR, token.IDENT, token.IF, token.ILLEGAL, token.IMAG, token.INC, token.INT, token.INTERFACE, token.LAND, token.LBRACE, token.LBRACK, token.LEQ, token.LOR, token.LPAREN, token.LSS, token.MAP, token.MUL, token.MUL_ASSIGN, token.NEQ, token.NOT, token.OR, token.OR_ASSIGN, token.PACKAGE, token.PERIOD, token.QUO, token.QUO_ASSIGN, token.RANGE, token.RBRACE, token.RBRACK, token.REM, token.REM_ASSIGN, token.RETURN, token.RPAREN, token.SELECT, token.SEMICOLON, token.SHL, token.SHL_ASSIGN, token.SHR, token.SHR_ASSIGN, token.STRING, token.STRUCT, token.SUB, token.SUB_ASSIGN, token.SWITCH, token.TILDE, token.XOR, token.XOR_ASSIGN: //nolint:lll
-				// nothing to do
-			}
-		}
+	case token.TYPE:
+		return ProcessTypeCoverage(file, fset, f, gdecl)
+
+	case token.ADD, token.ADD_ASSIGN, token.AND, token.AND_ASSIGN, token.AND_NOT, token.AND_NOT_ASSIGN, token.ARROW, token.ASSIGN, token.BREAK, token.CASE, token.CHAN, token.CHAR, token.COLON, token.COMMA, token.COMMENT, token.CONTINUE, token.DEC, token.DEFAULT, token.DEFER, token.DEFINE, token.ELLIPSIS, token.ELSE, token.EOF, token.EQL, token.FALLTHROUGH, token.FLOAT, token.FOR, token.FUNC, token.GEQ, token.GO, token.GOTO, token.GTR, token.IDENT, token.IF, token.ILLEGAL, token.IMAG, token.INC, token.INT, token.INTERFACE, token.LAND, token.LBRACE, token.LBRACK, token.LEQ, token.LOR, token.LPAREN, token.LSS, token.MAP, token.MUL, token.MUL_ASSIGN, token.NEQ, token.NOT, token.OR, token.OR_ASSIGN, token.PACKAGE, token.PERIOD, token.QUO, token.QUO_ASSIGN, token.RANGE, token.RBRACE, token.RBRACK, token.REM, token.REM_ASSIGN, token.RETURN, token.RPAREN, token.SELECT, token.SEMICOLON, token.SHL, token.SHL_ASSIGN, token.SHR, token.SHR_ASSIGN, token.STRING, token.STRUCT, token.SUB, token.SUB_ASSIGN, token.SWITCH, token.TILDE, token.XOR, token.XOR_ASSIGN: //nolint:lll
+		// nothing to do
 	}
 
-	return items
+	return []*proto.CoverageItem{}
 }
 
 // ProcessPackageCoverage measures the package level comment coverage.
@@ -94,9 +104,9 @@ func ProcessPackageCoverage(file string, fset *token.FileSet, f *ast.File) *prot
 		csp := fset.Position(cg.Pos())
 		cep := fset.Position(cg.End())
 
-		if IsHeader(fset, cg, block) && !IsOnlyNoLintAnnotation(cg.Text()) {
+		if IsHeader(fset, cg, block) && IsDocumentation(cg.Text()) {
 			d := &proto.Comment{
-				Comment: Normalize(cg.Text()),
+				Comment: NormalizeGroup(cg),
 				Block: &proto.Block{
 					StartLine:   safeIntToUint32(csp.Line),
 					StartColumn: safeIntToUint32(csp.Column),
@@ -107,9 +117,9 @@ func ProcessPackageCoverage(file string, fset *token.FileSet, f *ast.File) *prot
 			hcs = append(hcs, d)
 		}
 
-		if IsInline(fset, cg, block) && !IsOnlyNoLintAnnotation(cg.Text()) {
+		if IsInline(fset, cg, block) && IsDocumentation(cg.Text()) {
 			d := &proto.Comment{
-				Comment: Normalize(cg.Text()),
+				Comment: NormalizeGroup(cg),
 				Block: &proto.Block{
 					StartLine:   safeIntToUint32(csp.Line),
 					StartColumn: safeIntToUint32(csp.Column),
@@ -157,9 +167,9 @@ func ProcessFunctionCoverage(file string, fset *token.FileSet, f *ast.File, fdec
 		csp := fset.Position(cg.Pos())
 		cep := fset.Position(cg.End())
 
-		if IsHeader(fset, cg, block) && !IsOnlyNoLintAnnotation(cg.Text()) {
+		if IsHeader(fset, cg, block) && IsDocumentation(cg.Text()) {
 			d := &proto.Comment{
-				Comment: Normalize(cg.Text()),
+				Comment: NormalizeGroup(cg),
 				Block: &proto.Block{
 					StartLine:   safeIntToUint32(csp.Line),
 					StartColumn: safeIntToUint32(csp.Column),
@@ -170,9 +180,9 @@ func ProcessFunctionCoverage(file string, fset *token.FileSet, f *ast.File, fdec
 			hcs = append(hcs, d)
 		}
 
-		if IsInline(fset, cg, block) && !IsOnlyNoLintAnnotation(cg.Text()) {
+		if IsInline(fset, cg, block) && IsDocumentation(cg.Text()) {
 			d := &proto.Comment{
-				Comment: Normalize(cg.Text()),
+				Comment: NormalizeGroup(cg),
 				Block: &proto.Block{
 					StartLine:   safeIntToUint32(csp.Line),
 					StartColumn: safeIntToUint32(csp.Column),
@@ -226,9 +236,9 @@ func ProcessVariableCoverage(file string, fset *token.FileSet, f *ast.File, gdec
 				csp := fset.Position(cg.Pos())
 				cep := fset.Position(cg.End())
 
-				if IsHeader(fset, cg, block) && !IsOnlyNoLintAnnotation(cg.Text()) {
+				if IsHeader(fset, cg, block) && IsDocumentation(cg.Text()) {
 					d := &proto.Comment{
-						Comment: Normalize(cg.Text()),
+						Comment: NormalizeGroup(cg),
 						Block: &proto.Block{
 							StartLine:   safeIntToUint32(csp.Line),
 							StartColumn: safeIntToUint32(csp.Column),
@@ -239,9 +249,9 @@ func ProcessVariableCoverage(file string, fset *token.FileSet, f *ast.File, gdec
 					hcs = append(hcs, d)
 				}
 
-				if IsInline(fset, cg, block) && !IsOnlyNoLintAnnotation(cg.Text()) {
+				if IsInline(fset, cg, block) && IsDocumentation(cg.Text()) {
 					d := &proto.Comment{
-						Comment: Normalize(cg.Text()),
+						Comment: NormalizeGroup(cg),
 						Block: &proto.Block{
 							StartLine:   safeIntToUint32(csp.Line),
 							StartColumn: safeIntToUint32(csp.Column),
@@ -299,6 +309,14 @@ func ProcessTypeCoverage(file string, fset *token.FileSet, f *ast.File, gdecl *a
 			} else {
 				scope = proto.CoverageItem_PRIVATE_CLASS
 			}
+
+		default:
+			// the named types over the other types, like `type ID string`, `type P *T` or `type L = list.List`.
+			if ast.IsExported(Identifier) {
+				scope = proto.CoverageItem_PUBLIC_TYPE
+			} else {
+				scope = proto.CoverageItem_PRIVATE_TYPE
+			}
 		}
 
 		hcs := []*proto.Comment{}
@@ -307,9 +325,9 @@ func ProcessTypeCoverage(file string, fset *token.FileSet, f *ast.File, gdecl *a
 			csp := fset.Position(cg.Pos())
 			cep := fset.Position(cg.End())
 
-			if IsHeader(fset, cg, block) && !IsOnlyNoLintAnnotation(cg.Text()) {
+			if IsHeader(fset, cg, block) && IsDocumentation(cg.Text()) {
 				d := &proto.Comment{
-					Comment: Normalize(cg.Text()),
+					Comment: NormalizeGroup(cg),
 					Block: &proto.Block{
 						StartLine:   safeIntToUint32(csp.Line),
 						StartColumn: safeIntToUint32(csp.Column),
@@ -320,9 +338,9 @@ func ProcessTypeCoverage(file string, fset *token.FileSet, f *ast.File, gdecl *a
 				hcs = append(hcs, d)
 			}
 
-			if IsInline(fset, cg, block) && !IsOnlyNoLintAnnotation(cg.Text()) {
+			if IsInline(fset, cg, block) && IsDocumentation(cg.Text()) {
 				d := &proto.Comment{
-					Comment: Normalize(cg.Text()),
+					Comment: NormalizeGroup(cg),
 					Block: &proto.Block{
 						StartLine:   safeIntToUint32(csp.Line),
 						StartColumn: safeIntToUint32(csp.Column),
@@ -348,6 +366,89 @@ func ProcessTypeCoverage(file string, fset *token.FileSet, f *ast.File, gdecl *a
 	return items
 }
 
+// ProcessFieldCoverage measures the comment coverage of the fields of the struct type.
+// The fields are identified as `Type.Field`, and the embedded fields are named after their types.
+// It returns no items if the type is not a struct type.
+func ProcessFieldCoverage(file string, fset *token.FileSet, f *ast.File, ts *ast.TypeSpec) []*proto.CoverageItem {
+	items := make([]*proto.CoverageItem, 0)
+
+	st, ok := ts.Type.(*ast.StructType)
+	if !ok {
+		return items
+	}
+
+	for _, field := range st.Fields.List {
+		names := []string{}
+		for _, n := range field.Names {
+			names = append(names, n.Name)
+		}
+		if len(field.Names) == 0 {
+			names = append(names, embeddedTypeName(field.Type))
+		}
+
+		sp := fset.Position(field.Pos())
+		ep := fset.Position(field.End())
+		block := &proto.Block{
+			StartLine:   safeIntToUint32(sp.Line),
+			StartColumn: safeIntToUint32(sp.Column),
+			EndLine:     safeIntToUint32(ep.Line),
+			EndColumn:   safeIntToUint32(ep.Column),
+		}
+
+		hcs := []*proto.Comment{}
+		ics := []*proto.Comment{}
+		for _, cg := range f.Comments {
+			csp := fset.Position(cg.Pos())
+			cep := fset.Position(cg.End())
+
+			if IsHeader(fset, cg, block) && IsDocumentation(cg.Text()) {
+				d := &proto.Comment{
+					Comment: NormalizeGroup(cg),
+					Block: &proto.Block{
+						StartLine:   safeIntToUint32(csp.Line),
+						StartColumn: safeIntToUint32(csp.Column),
+						EndLine:     safeIntToUint32(cep.Line),
+						EndColumn:   safeIntToUint32(cep.Column),
+					},
+				}
+				hcs = append(hcs, d)
+			}
+
+			if IsInline(fset, cg, block) && IsDocumentation(cg.Text()) {
+				d := &proto.Comment{
+					Comment: NormalizeGroup(cg),
+					Block: &proto.Block{
+						StartLine:   safeIntToUint32(csp.Line),
+						StartColumn: safeIntToUint32(csp.Column),
+						EndLine:     safeIntToUint32(cep.Line),
+						EndColumn:   safeIntToUint32(cep.Column),
+					},
+				}
+				ics = append(ics, d)
+			}
+		}
+
+		for _, name := range names {
+			scope := proto.CoverageItem_PRIVATE_VARIABLE
+			if ast.IsExported(name) {
+				scope = proto.CoverageItem_PUBLIC_VARIABLE
+			}
+
+			items = append(items, &proto.CoverageItem{
+				Scope:          scope,
+				TargetBlock:    block,
+				File:           file,
+				Identifier:     ReachabilityKey(ts.Name.Name, name),
+				Extension:      filepath.Ext(file),
+				HeaderComments: hcs,
+				InlineComments: ics,
+			})
+		}
+	}
+
+	return items
+}
+
 // IsHeader returns true if the given *ast.CommentGroup is belonged to the given *proto.Block as HeaderComments.
 func IsHeader(fset *token.FileSet, cg *ast.CommentGroup, b *proto.Block) bool {
 	csp := fset.Position(cg.Pos())
@@ -794,7 +794,7 @@ func MyFunc() bool { // MyFunc Inline
 								EndLine:     2,
 								EndColumn:   19,
 							},
-							Comment: "hoge Header\n hoge Header2\n",
+							Comment: "hoge Header\nhoge Header2\n",
 						},
 					},
 					InlineComments: []*proto.Comment{
@@ -829,7 +829,7 @@ func MyFunc() bool { // MyFunc Inline
 								EndLine:     6,
 								EndColumn:   20,
 							},
-							Comment: "MyVar Header\n MyVar Header2\n",
+							Comment: "MyVar Header\nMyVar Header2\n",
 						},
 					},
 					InlineComments: []*proto.Comment{
@@ -864,7 +864,7 @@ func MyFunc() bool { // MyFunc Inline
 								EndLine:     11,
 								EndColumn:   24,
 							},
-							Comment: "MyVar Header\n MyVar Header2\n",
+							Comment: "MyVar Header\nMyVar Header2\n",
 						},
 					},
 					InlineComments: []*proto.Comment{
@@ -899,7 +899,7 @@ func MyFunc() bool { // MyFunc Inline
 								EndLine:     17,
 								EndColumn:   22,
 							},
-							Comment: "MyConst Header\n MyConst Header2\n",
+							Comment: "MyConst Header\nMyConst Header2\n",
 						},
 					},
 					InlineComments: []*proto.Comment{
@@ -934,7 +934,7 @@ func MyFunc() bool { // MyFunc Inline
 								EndLine:     22,
 								EndColumn:   26,
 							},
-							Comment: "MyConst Header\n MyConst Header2\n",
+							Comment: "MyConst Header\nMyConst Header2\n",
 						},
 					},
 					InlineComments: []*proto.Comment{
@@ -969,7 +969,7 @@ func MyFunc() bool { // MyFunc Inline
 								EndLine:     27,
 								EndColumn:   23,
 							},
-							Comment: "MyStruct Header\n MyStruct Header2\n",
+							Comment: "MyStruct Header\nMyStruct Header2\n",
 						},
 					},
 					InlineComments: []*proto.Comment{
@@ -1031,7 +1031,7 @@ func MyFunc() bool { // MyFunc Inline
 								EndLine:     37,
 								EndColumn:   27,
 							},
-							Comment: "MyStruct Header\n MyStruct Header2\n",
+							Comment: "MyStruct Header\nMyStruct Header2\n",
 						},
 					},
 					InlineComments: []*proto.Comment{
@@ -1093,7 +1093,7 @@ func MyFunc() bool { // MyFunc Inline
 								EndLine:     47,
 								EndColumn:   26,
 							},
-							Comment: "MyInterface Header\n MyInterface Header2\n",
+							Comment: "MyInterface Header\nMyInterface Header2\n",
 						},
 					},
 					InlineComments: []*proto.Comment{
@@ -1155,7 +1155,7 @@ func MyFunc() bool { // MyFunc Inline
 								EndLine:     57,
 								EndColumn:   30,
 							},
-							Comment: "MyInterface Header\n MyInterface Header2\n",
+							Comment: "MyInterface Header\nMyInterface Header2\n",
 						},
 					},
 					InlineComments: []*proto.Comment{
@@ -1217,7 +1217,7 @@ func MyFunc() bool { // MyFunc Inline
 								EndLine:     67,
 								EndColumn:   21,
 							},
-							Comment: "MyType Header\n MyType Header2\n",
+							Comment: "MyType Header\nMyType Header2\n",
 						},
 					},
 					InlineComments: []*proto.Comment{
@@ -1252,7 +1252,7 @@ func MyFunc() bool { // MyFunc Inline
 								EndLine:     72,
 								EndColumn:   25,
 							},
-							Comment: "MyType Header\n MyType Header2\n",
+							Comment: "MyType Header\nMyType Header2\n",
 						},
 					},
 					InlineComments: []*proto.Comment{
@@ -1287,7 +1287,7 @@ func MyFunc() bool { // MyFunc Inline
 								EndLine:     77,
 								EndColumn:   21,
 							},
-							Comment: "MyFunc Header\n MyFunc Header2\n",
+							Comment: "MyFunc Header\nMyFunc Header2\n",
 						},
 					},
 					InlineComments: []*proto.Comment{
@@ -1470,7 +1470,7 @@ package hoge // hoge Inline
 							EndLine:     2,
 							EndColumn:   19,
 						},
-						Comment: "hoge Header\n hoge Header2\n",
+						Comment: "hoge Header\nhoge Header2\n",
 					},
 				},
 				InlineComments: []*proto.Comment{
@@ -1823,7 +1823,7 @@ func MyFunc() bool { // MyFunc Inline
 							EndLine:     5,
 							EndColumn:   21,
 						},
-						Comment: "MyFunc Header\n MyFunc Header2\n",
+						Comment: "MyFunc Header\nMyFunc Header2\n",
 					},
 				},
 				InlineComments: []*proto.Comment{
@@ -2359,7 +2359,7 @@ var MyVar = func() string { // MyVar Inline
 								EndLine:     5,
 								EndColumn:   20,
 							},
-							Comment: "MyVar Header\n MyVar Header2\n",
+							Comment: "MyVar Header\nMyVar Header2\n",
 						},
 					},
 					InlineComments: []*proto.Comment{
@@ -2805,7 +2805,7 @@ var ( // Out of MyVar
 								EndLine:     7,
 								EndColumn:   24,
 							},
-							Comment: "MyVar Header\n MyVar Header2\n",
+							Comment: "MyVar Header\nMyVar Header2\n",
 						},
 					},
 					InlineComments: []*proto.Comment{
@@ -3184,7 +3184,7 @@ const MyConst string = "string" // MyConst Inline
 								EndLine:     5,
 								EndColumn:   22,
 							},
-							Comment: "MyConst Header\n MyConst Header2\n",
+							Comment: "MyConst Header\nMyConst Header2\n",
 						},
 					},
 					InlineComments: []*proto.Comment{
@@ -3575,7 +3575,7 @@ const ( // Out of MyConst
 								EndLine:     7,
 								EndColumn:   26,
 							},
-							Comment: "MyConst Header\n MyConst Header2\n",
+							Comment: "MyConst Header\nMyConst Header2\n",
 						},
 					},
 					InlineComments: []*proto.Comment{
@@ -4147,7 +4147,7 @@ type MyStruct struct { // MyStruct Inline
 								EndLine:     5,
 								EndColumn:   23,
 							},
-							Comment: "MyStruct Header\n MyStruct Header2\n",
+							Comment: "MyStruct Header\nMyStruct Header2\n",
 						},
 					},
 					InlineComments: []*proto.Comment{
@@ -4739,7 +4739,7 @@ type ( // Out of MyStruct
 								EndLine:     7,
 								EndColumn:   27,
 							},
-							Comment: "MyStruct Header\n MyStruct Header2\n",
+							Comment: "MyStruct Header\nMyStruct Header2\n",
 						},
 					},
 					InlineComments: []*proto.Comment{
@@ -5288,7 +5288,7 @@ type MyInterface interface { // MyInterface Inline
 								EndLine:     5,
 								EndColumn:   26,
 							},
-							Comment: "MyInterface Header\n MyInterface Header2\n",
+							Comment: "MyInterface Header\nMyInterface Header2\n",
 						},
 					},
 					InlineComments: []*proto.Comment{
@@ -5880,7 +5880,7 @@ type ( // Out of MyInterface
 								EndLine:     7,
 								EndColumn:   30,
 							},
-							Comment: "MyInterface Header\n MyInterface Header2\n",
+							Comment: "MyInterface Header\nMyInterface Header2\n",
 						},
 					},
 					InlineComments: []*proto.Comment{
@@ -6356,7 +6356,7 @@ type MyType = map[string]int // MyType Inline
 								EndLine:     5,
 								EndColumn:   21,
 							},
-							Comment: "MyType Header\n MyType Header2\n",
+							Comment: "MyType Header\nMyType Header2\n",
 						},
 					},
 					InlineComments: []*proto.Comment{
@@ -6749,7 +6749,7 @@ type ( // Out of MyType
 								EndLine:     7,
 								EndColumn:   25,
 							},
-							Comment: "MyType Header\n MyType Header2\n",
+							Comment: "MyType Header\nMyType Header2\n",
 						},
 					},
 					InlineComments: []*proto.Comment{
@@ -0,0 +1,156 @@
+package ast
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+
+	"github.com/commentcov/commentcov/proto"
+)
+
+// CommentedOutCodeCheck is the name of the check for the commented-out code.
+const CommentedOutCodeCheck = "commented_out_code"
+
+// IsDocumentation returns true if the given comment text is counted as the documentation.
+// The nolint annotations and the commented-out code are not.
+func IsDocumentation(str string) bool {
+	return !IsOnlyNoLintAnnotation(str) && !IsCommentedOutCode(str)
+}
+
+// IsCommentedOutCode returns true if the given comment text parses as Go declarations or statements.
+// The unbalanced braces like `if err != nil {` are closed before parsing.
+// The texts parsed only as trivial statements, like a single word, `Note: width * height` or the list items `- a`,
+// are not regarded as code. The expressions like `e.g. foo(bar)` are regarded as code only if there are more than one,
+// while the statements prose never parses as, like `return x` or `x := 1`, are regarded as code alone.
+func IsCommentedOutCode(str string) bool {
+	src := strings.TrimSpace(str)
+	if src == "" {
+		return false
+	}
+
+	if n := strings.Count(src, "{") - strings.Count(src, "}"); n > 0 {
+		src += strings.Repeat("\n}", n)
+	} else if n < 0 {
+		src = strings.Repeat("{\n", -n) + src
+	}
+
+	fset := token.NewFileSet()
+	if f, err := parser.ParseFile(fset, "", "package p\n"+src, parser.SkipObjectResolution); err == nil && len(f.Decls) > 0 {
+		return true
+	}
+
+	f, err := parser.ParseFile(fset, "", "package p\nfunc _() {\n"+src+"\n}", parser.SkipObjectResolution)
+	if err != nil {
+		return false
+	}
+
+	exprs := 0
+	for _, stmt := range f.Decls[0].(*ast.FuncDecl).Body.List {
+		if isTrivialStmt(stmt) {
+			continue
+		}
+
+		if _, ok := stmt.(*ast.ExprStmt); !ok {
+			return true
+		}
+		exprs++
+	}
+
+	return exprs > 1
+}
+
+// isTrivialStmt returns true if the statement is likely to be parsed from prose,
+// like a single word, a list item or a sentence following a label like `Note:`.
+func isTrivialStmt(stmt ast.Stmt) bool {
+	switch s := stmt.(type) {
+	case *ast.EmptyStmt, *ast.LabeledStmt:
+		return true
+	case *ast.ExprStmt:
+		return isTrivialExpr(s.X) || isListItem(s.X)
+	}
+
+	return false
+}
+
+// isTrivialExpr returns true if the expression is an identifier, a literal or a selector of them.
+func isTrivialExpr(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.Ident, *ast.BasicLit:
+		return true
+	case *ast.SelectorExpr:
+		return isTrivialExpr(e.X)
+	}
+
+	return false
+}
+
+// isListItem returns true if the expression is parsed from a list item marked by `-`, `+` or `*`.
+func isListItem(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.UnaryExpr:
+		return e.Op == token.SUB || e.Op == token.ADD
+	case *ast.StarExpr:
+		return true
+	}
+
+	return false
+}
+
+// withCommentedOutCode returns the comments with the comment groups of the commented-out code added back,
+// which belong to the block by belongs, in the order of the comment groups in the file.
+// The comments are the ones of the CoverageItem measured by the Process*Coverage functions excluding the commented-out code.
+func withCommentedOutCode(
+	fset *token.FileSet, f *ast.File, block *proto.Block, comments []*proto.Comment,
+	belongs func(*token.FileSet, *ast.CommentGroup, *proto.Block) bool,
+) []*proto.Comment {
+	merged := []*proto.Comment{}
+	i := 0
+	for _, cg := range f.Comments {
+		if !belongs(fset, cg, block) {
+			continue
+		}
+
+		b := NewBlock(fset, cg.Pos(), cg.End())
+		if i < len(comments) && comments[i].Block.StartLine == b.StartLine && comments[i].Block.StartColumn == b.StartColumn {
+			merged = append(merged, comments[i])
+			i++
+			continue
+		}
+
+		if !IsOnlyNoLintAnnotation(cg.Text()) && IsCommentedOutCode(cg.Text()) {
+			merged = append(merged, &proto.Comment{Comment: NormalizeGroup(cg), Block: b})
+		}
+	}
+
+	return merged
+}
+
+// CheckCommentedOutCode reports the comment groups in the file which are commented-out code.
+// Each Diagnostic is attributed to the CoverageItem which the comment group would belong to as a header or an inline comment.
+func CheckCommentedOutCode(file string, fset *token.FileSet, f *ast.File, items []*proto.CoverageItem) []*Diagnostic {
+	ds := []*Diagnostic{}
+	for _, cg := range f.Comments {
+		if !IsCommentedOutCode(cg.Text()) {
+			continue
+		}
+
+		identifier := ""
+		for _, ci := range items {
+			if IsHeader(fset, cg, ci.TargetBlock) || IsInline(fset, cg, ci.TargetBlock) {
+				identifier = ci.Identifier
+				break
+			}
+		}
+
+		ds = append(ds, &Diagnostic{
+			Check:      CommentedOutCodeCheck,
+			File:       file,
+			Identifier: identifier,
+			Block:      NewBlock(fset, cg.Pos(), cg.End()),
+			Message:    "comment is commented-out code",
+		})
+	}
+
+	return ds
+}
@@ -0,0 +1,176 @@
+package ast_test
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/commentcov/commentcov/proto"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	myAst "github.com/commentcov/commentcov-plugin-go/ast"
+)
+
+// TestIsCommentedOutCode is the unittest for IsCommentedOutCode.
+func TestIsCommentedOutCode(t *testing.T) {
+	tests := []struct {
+		name string
+		str  string
+		want bool
+	}{
+		{name: "return statement", str: "return x + y\n", want: true},
+		{name: "unclosed if statement", str: "if err != nil {\n", want: true},
+		{name: "closing brace", str: "}\n", want: true},
+		{name: "unopened block", str: "\treturn err\n}\n", want: true},
+		{name: "assignment", str: "x := compute(a, b)\n", want: true},
+		{name: "function calls", str: "fmt.Println(\"debug\")\nos.Exit(1)\n", want: true},
+		{name: "declaration", str: "func old() error {\n\treturn nil\n}\n", want: true},
+		{name: "var declaration", str: "var cache map[string]int\n", want: true},
+		{name: "prose", str: "MyFunc returns the value.\n", want: false},
+		{name: "single word", str: "Deprecated\n", want: false},
+		{name: "label like", str: "TODO: fix\n", want: false},
+		{name: "url", str: "https://example.com\n", want: false},
+		{name: "selector", str: "fmt.Println\n", want: false},
+		{name: "empty", str: "", want: false},
+		{name: "list", str: "Options:\n  - a\n  - b\n", want: false},
+		{name: "list with asterisks", str: "Options:\n  * a\n  * b\n", want: false},
+		{name: "labeled call", str: "Example: Run(1, 2)\n", want: false},
+		{name: "labeled command", str: "Usage: mytool -v\n", want: false},
+		{name: "labeled expression", str: "Note: width * height\n", want: false},
+		{name: "single call in prose", str: "e.g. foo(bar)\n", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := myAst.IsCommentedOutCode(tt.str)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("bool values are mismatch (-want +got):%s\n", diff)
+			}
+		})
+	}
+}
+
+// TestCheckCommentedOutCode is the unittest for CheckCommentedOutCode and the exclusion from the coverage.
+func TestCheckCommentedOutCode(t *testing.T) {
+	src := `package hoge
+
+// return x + y
+func MyFunc() bool {
+    // if err != nil {
+    return true // MyFunc Inline
+}
+
+// x := 1
+`
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "hoge.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	items := myAst.ProcessFileCoverage("hoge.go", fset, f)
+	for _, ci := range items {
+		if ci.Identifier != "MyFunc" {
+			continue
+		}
+
+		if len(ci.HeaderComments) != 0 {
+			t.Errorf("commented-out code is counted as HeaderComments: %v", ci.HeaderComments)
+		}
+		if len(ci.InlineComments) != 1 {
+			t.Errorf("commented-out code is counted as InlineComments: %v", ci.InlineComments)
+		}
+	}
+
+	want := []*myAst.Diagnostic{
+		{
+			Check:      myAst.CommentedOutCodeCheck,
+			File:       "hoge.go",
+			Identifier: "MyFunc",
+			Block:      &proto.Block{StartLine: 3, StartColumn: 1, EndLine: 3, EndColumn: 16},
+			Message:    "comment is commented-out code",
+		},
+		{
+			Check:      myAst.CommentedOutCodeCheck,
+			File:       "hoge.go",
+			Identifier: "MyFunc",
+			Block:      &proto.Block{StartLine: 5, StartColumn: 5, EndLine: 5, EndColumn: 23},
+			Message:    "comment is commented-out code",
+		},
+		{
+			Check:      myAst.CommentedOutCodeCheck,
+			File:       "hoge.go",
+			Identifier: "",
+			Block:      &proto.Block{StartLine: 9, StartColumn: 1, EndLine: 9, EndColumn: 10},
+			Message:    "comment is commented-out code",
+		},
+	}
+
+	got := myAst.CheckCommentedOutCode("hoge.go", fset, f, items)
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreUnexported(proto.Block{})); diff != "" {
+		t.Errorf("Diagnostic values are mismatch (-want +got):%s\n", diff)
+	}
+}
+
+// TestAnalyzeFile_CommentedOutCode is the unittest for the exclusion of the commented-out code by Analyzer.AnalyzeFile.
+func TestAnalyzeFile_CommentedOutCode(t *testing.T) {
+	src := `// Package hoge is a package.
+package hoge
+
+// Options:
+//   - a
+//   - b
+var Options int
+
+// Example: Run(1, 2)
+func Run(a, b int) {}
+
+// return x + y
+func Old() {}
+`
+	file := filepath.Join(t.TempDir(), "hoge.go")
+	if err := os.WriteFile(file, []byte(src), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		disabled bool
+		want     map[string]int
+	}{
+		{
+			name: "enabled",
+			want: map[string]int{"hoge": 1, "Options": 1, "Run": 1, "Old": 0},
+		},
+		{
+			name:     "disabled",
+			disabled: true,
+			want:     map[string]int{"hoge": 1, "Options": 1, "Run": 1, "Old": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := myAst.DefaultOptions()
+			options.DisabledChecks[myAst.CommentedOutCodeCheck] = tt.disabled
+
+			res, err := myAst.NewAnalyzer(options).AnalyzeFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := map[string]int{}
+			for _, ci := range res.Items {
+				got[ci.Identifier] = len(ci.HeaderComments)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("HeaderComments values are mismatch (-want +got):%s\n", diff)
+			}
+		})
+	}
+}
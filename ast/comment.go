@@ -1,12 +1,178 @@
 package ast
 
 import (
+	"go/ast"
+	"go/doc/comment"
 	"strings"
+
+	"github.com/commentcov/commentcov/proto"
 )
 
+// decorativeChars are the characters used to draw banners and separators in comments.
+const decorativeChars = "/*-=#~_+"
+
+// minDecorationLength is the minimum length of a run of decorativeChars regarded as a decoration.
+const minDecorationLength = 3
+
 // Normalize the given comment text.
+// It strips the common indentation, the trailing whitespace and the banner lines,
+// then re-prints it in the standard doc comment format via go/doc/comment.
+// The ` * ` gutters of the block comments are not stripped, use NormalizeGroup for the comment groups.
 func Normalize(str string) string {
-	return strings.TrimLeft(str, " ")
+	return normalize(str, false)
+}
+
+// NormalizeGroup normalizes the text of the comment group like Normalize.
+// The ` * ` gutters are stripped as well if the group consists of the block comments,
+// since the lines starting with `*` in the line comments are the list items.
+func NormalizeGroup(cg *ast.CommentGroup) string {
+	return normalize(cg.Text(), isBlockGroup(cg))
+}
+
+// isBlockGroup returns true if all the comments of the group are the block comments.
+func isBlockGroup(cg *ast.CommentGroup) bool {
+	for _, c := range cg.List {
+		if !strings.HasPrefix(c.Text, "/*") {
+			return false
+		}
+	}
+
+	return len(cg.List) > 0
+}
+
+// normalize normalizes the comment text, stripping the gutters if block is true.
+func normalize(str string, block bool) string {
+	str = strings.ReplaceAll(str, "\r\n", "\n")
+	str = strings.ReplaceAll(str, "\r", "\n")
+
+	lines := strings.Split(str, "\n")
+	if block {
+		lines = stripGutter(lines)
+	}
+	lines = stripDecorations(lines)
+	lines = dedent(lines)
+
+	var p comment.Parser
+	doc := p.Parse(strings.Join(lines, "\n"))
+
+	var pr comment.Printer
+	return string(pr.Comment(doc))
+}
+
+// stripGutter removes the leading ` * ` of the block comment lines.
+// The gutter is stripped only if every non-blank line except the first one has it.
+// The first line follows `/*` directly then, so its leading whitespace is dropped as well.
+func stripGutter(lines []string) []string {
+	found := false
+	for i, l := range lines {
+		if strings.TrimSpace(l) == "" {
+			continue
+		}
+
+		if !hasGutter(l) {
+			if i == 0 {
+				continue
+			}
+
+			return lines
+		}
+
+		found = true
+	}
+
+	if !found {
+		return lines
+	}
+
+	stripped := make([]string, 0, len(lines))
+	for i, l := range lines {
+		if i == 0 && !hasGutter(l) {
+			l = strings.TrimLeft(l, " \t")
+		}
+
+		if hasGutter(l) {
+			l = strings.TrimLeft(l, " \t")
+			l = strings.TrimLeft(l, "*")
+			l = strings.TrimPrefix(l, " ")
+		}
+
+		stripped = append(stripped, l)
+	}
+
+	return stripped
+}
+
+// hasGutter returns true if the given line starts with ` * `.
+func hasGutter(line string) bool {
+	l := strings.TrimLeft(line, " \t")
+	if !strings.HasPrefix(l, "*") {
+		return false
+	}
+
+	l = strings.TrimLeft(l, "*")
+	return l == "" || strings.HasPrefix(l, " ") || strings.HasPrefix(l, "\t")
+}
+
+// stripDecorations removes the trailing whitespace and the banners like `/////` or `=====` from the lines.
+// The lines consisting only of decorations are turned into blank lines,
+// and the decorative characters in the other lines, like the ones of `/usr/local/***`, are kept.
+func stripDecorations(lines []string) []string {
+	stripped := make([]string, 0, len(lines))
+	for _, l := range lines {
+		l = strings.TrimRight(l, " \t")
+
+		body := strings.TrimLeft(l, " \t")
+		if len(body) >= minDecorationLength && strings.Trim(body, decorativeChars) == "" {
+			l = ""
+		}
+
+		stripped = append(stripped, l)
+	}
+
+	return stripped
+}
+
+// dedent removes the indentation shared by all the non-blank lines.
+func dedent(lines []string) []string {
+	prefix := ""
+	first := true
+	for _, l := range lines {
+		if l == "" {
+			continue
+		}
+
+		indent := l[:len(l)-len(strings.TrimLeft(l, " \t"))]
+		if first {
+			prefix = indent
+			first = false
+			continue
+		}
+
+		for !strings.HasPrefix(indent, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+
+	if prefix == "" {
+		return lines
+	}
+
+	dedented := make([]string, 0, len(lines))
+	for _, l := range lines {
+		dedented = append(dedented, strings.TrimPrefix(l, prefix))
+	}
+
+	return dedented
+}
+
+// commentsText returns the texts of the comments joined.
+func commentsText(cs []*proto.Comment) string {
+	texts := make([]string, 0, len(cs))
+	for _, c := range cs {
+		texts = append(texts, c.Comment)
+	}
+
+	return strings.Join(texts, "\n")
 }
 
 func IsOnlyNoLintAnnotation(str string) bool {
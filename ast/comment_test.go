@@ -1,6 +1,8 @@
 package ast_test
 
 import (
+	"go/parser"
+	"go/token"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -19,27 +21,57 @@ func TestNormalize(t *testing.T) {
 		{
 			name: "no prefix whitespace",
 			str:  "hoge",
-			want: "hoge",
+			want: "hoge\n",
 		},
 		{
 			name: "prefix whitespace",
 			str:  " hoge",
-			want: "hoge",
+			want: "hoge\n",
 		},
 		{
 			name: "multi prefix whitespace",
 			str:  "   hoge",
-			want: "hoge",
+			want: "hoge\n",
 		},
 		{
 			name: "prefix and suffix whitespace",
 			str:  " hoge ",
-			want: "hoge ",
+			want: "hoge\n",
 		},
 		{
 			name: "whitespace and return code",
 			str:  " hoge \n fuga ",
-			want: "hoge \n fuga ",
+			want: "hoge\nfuga\n",
+		},
+		{
+			name: "tab indentation",
+			str:  "\thoge\n\tfuga\n",
+			want: "hoge\nfuga\n",
+		},
+		{
+			name: "crlf",
+			str:  "hoge\r\nfuga\r\n",
+			want: "hoge\nfuga\n",
+		},
+		{
+			name: "banner lines",
+			str:  "////////\nhoge\n////////\n",
+			want: "hoge\n",
+		},
+		{
+			name: "decorations in the text are kept",
+			str:  "Path is /usr/local/***\n",
+			want: "Path is /usr/local/***\n",
+		},
+		{
+			name: "code block is kept",
+			str:  "hoge:\n\n\tfuga()\n",
+			want: "hoge:\n\n\tfuga()\n",
+		},
+		{
+			name: "empty",
+			str:  "",
+			want: "",
 		},
 	}
 
@@ -53,6 +85,50 @@ func TestNormalize(t *testing.T) {
 	}
 }
 
+// TestNormalizeGroup is the unittest for NormalizeGroup.
+func TestNormalizeGroup(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "block comment with gutter",
+			src:  "/**\n * hoge\n * fuga\n */",
+			want: "hoge\nfuga\n",
+		},
+		{
+			name: "block comment with gutter from the second line",
+			src:  "/* hoge\n * fuga\n *\n * piyo\n */",
+			want: "hoge\nfuga\n\npiyo\n",
+		},
+		{
+			name: "list in line comments",
+			src:  "// Options:\n//   - a\n//   - b",
+			want: "Options:\n  - a\n  - b\n",
+		},
+		{
+			name: "list with asterisks in line comments",
+			src:  "// Options:\n//   * a\n//   * b",
+			want: "Options:\n  - a\n  - b\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := parser.ParseFile(token.NewFileSet(), "hoge.go", tt.src+"\npackage hoge\n", parser.ParseComments)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := ast.NormalizeGroup(f.Doc)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("string values are mismatch (-want +got):%s\n", diff)
+			}
+		})
+	}
+}
+
 // TestIsOnlyNoLintAnnotation is the unittest for IsOnlyNoLintAnnotation.
 func TestIsOnlyNoLintAnnotation(t *testing.T) {
 	tests := []struct {
@@ -0,0 +1,80 @@
+package ast
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"regexp"
+
+	"github.com/commentcov/commentcov/proto"
+)
+
+// ConcurrencyDocCheck is the name of the check for the documentation of the concurrency safety.
+const ConcurrencyDocCheck = "concurrency_doc"
+
+// concurrencyWordRe matches the wordings about the concurrency safety.
+var concurrencyWordRe = regexp.MustCompile(`(?i)\b(concurren(t|tly|cy)|goroutines?|thread[- ]?safe(ty)?|synchroniz(ed|ation)|parallel)\b`)
+
+// syncTypes are the types of the sync and the sync/atomic packages which imply the concurrent use.
+var syncTypes = map[string][]string{
+	"sync":        {"Mutex", "RWMutex"},
+	"sync/atomic": {"Bool", "Int32", "Int64", "Uint32", "Uint64", "Uintptr", "Pointer", "Value"},
+}
+
+// CheckConcurrencyDoc reports the exported struct type which has a lock or atomic field, named or embedded,
+// but whose header comments do not state whether it is safe for concurrent use.
+// The Diagnostic is positioned at the first such field.
+func CheckConcurrencyDoc(file string, fset *token.FileSet, f *ast.File, spec *ast.TypeSpec, ci *proto.CoverageItem) []*Diagnostic {
+	st, ok := spec.Type.(*ast.StructType)
+	if !ok || !ast.IsExported(spec.Name.Name) || !isDocumented(ci) {
+		return []*Diagnostic{}
+	}
+
+	field, typ := findSyncField(f, st)
+	if field == nil || concurrencyWordRe.MatchString(commentsText(ci.HeaderComments)) {
+		return []*Diagnostic{}
+	}
+
+	return []*Diagnostic{
+		{
+			Check:      ConcurrencyDocCheck,
+			File:       file,
+			Identifier: ci.Identifier,
+			Block:      NewBlock(fset, field.Pos(), field.End()),
+			Message:    fmt.Sprintf("type has a %s field but its comment does not state whether it is safe for concurrent use", typ),
+		},
+	}
+}
+
+// findSyncField returns the first field of the struct typed with syncTypes, and the type name.
+func findSyncField(f *ast.File, st *ast.StructType) (*ast.Field, string) {
+	imports := ImportNames(f)
+
+	for _, field := range st.Fields.List {
+		expr := field.Type
+		if star, ok := expr.(*ast.StarExpr); ok {
+			expr = star.X
+		}
+		if idx, ok := expr.(*ast.IndexExpr); ok {
+			expr = idx.X
+		}
+
+		sel, ok := expr.(*ast.SelectorExpr)
+		if !ok {
+			continue
+		}
+
+		pkg, ok := sel.X.(*ast.Ident)
+		if !ok {
+			continue
+		}
+
+		for _, name := range syncTypes[imports[pkg.Name]] {
+			if sel.Sel.Name == name {
+				return field, pkg.Name + "." + name
+			}
+		}
+	}
+
+	return nil, ""
+}
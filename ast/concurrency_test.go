@@ -0,0 +1,127 @@
+package ast_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/commentcov/commentcov/proto"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	myAst "github.com/commentcov/commentcov-plugin-go/ast"
+)
+
+// TestCheckConcurrencyDoc is the unittest for CheckConcurrencyDoc.
+//
+//nolint:funlen
+func TestCheckConcurrencyDoc(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []*myAst.Diagnostic
+	}{
+		{
+			name: "concurrency safety stated",
+			src: `package hoge
+
+import "sync"
+
+// MyCache caches the values. It is safe for concurrent use.
+type MyCache struct {
+    mu sync.Mutex
+}
+
+// MyCounter counts. It must not be copied nor used from multiple goroutines.
+type MyCounter struct {
+    sync.RWMutex
+}
+`,
+			want: []*myAst.Diagnostic{},
+		},
+		{
+			name: "concurrency safety not stated",
+			src: `package hoge
+
+import (
+    "sync"
+    satomic "sync/atomic"
+)
+
+// MyCache caches the values.
+type MyCache struct {
+    values map[string]string
+    *sync.RWMutex
+}
+
+// MyCounter counts.
+type MyCounter struct {
+    n satomic.Int64
+}
+`,
+			want: []*myAst.Diagnostic{
+				{
+					Check:      myAst.ConcurrencyDocCheck,
+					File:       "hoge.go",
+					Identifier: "MyCache",
+					Block:      &proto.Block{StartLine: 11, StartColumn: 5, EndLine: 11, EndColumn: 18},
+					Message:    "type has a sync.RWMutex field but its comment does not state whether it is safe for concurrent use",
+				},
+				{
+					Check:      myAst.ConcurrencyDocCheck,
+					File:       "hoge.go",
+					Identifier: "MyCounter",
+					Block:      &proto.Block{StartLine: 16, StartColumn: 5, EndLine: 16, EndColumn: 20},
+					Message:    "type has a satomic.Int64 field but its comment does not state whether it is safe for concurrent use",
+				},
+			},
+		},
+		{
+			name: "not checked",
+			src: `package hoge
+
+import "sync"
+
+// myCache caches the values.
+type myCache struct {
+    mu sync.Mutex
+}
+
+type NoComment struct {
+    mu sync.Mutex
+}
+
+// NoLock has no lock.
+type NoLock struct {
+    wg sync.WaitGroup
+}
+`,
+			want: []*myAst.Diagnostic{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			f, err := parser.ParseFile(fset, "hoge.go", tt.src, parser.ParseComments)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := []*myAst.Diagnostic{}
+			for _, decl := range f.Decls {
+				if d, ok := decl.(*ast.GenDecl); ok && d.Tok == token.TYPE {
+					specs := myAst.TypeSpecs(d)
+					for _, ci := range myAst.ProcessTypeCoverage("hoge.go", fset, f, d) {
+						got = append(got, myAst.CheckConcurrencyDoc("hoge.go", fset, f, specs[ci.Identifier], ci)...)
+					}
+				}
+			}
+
+			if diff := cmp.Diff(tt.want, got, cmpopts.IgnoreUnexported(proto.Block{})); diff != "" {
+				t.Errorf("Diagnostic values are mismatch (-want +got):%s\n", diff)
+			}
+		})
+	}
+}
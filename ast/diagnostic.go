@@ -0,0 +1,84 @@
+package ast
+
+import (
+	"go/ast"
+	"go/token"
+	"regexp"
+	"strings"
+
+	"github.com/commentcov/commentcov/proto"
+)
+
+// Diagnostic is a problem found in the comments, reported alongside the CoverageItems.
+type Diagnostic struct {
+	// Check is the name of the check which found the problem.
+	Check string
+	// File is the file where the problem is.
+	File string
+	// Identifier is the identifier of the CoverageItem which the problem belongs to.
+	Identifier string
+	// Block is the position of the problem.
+	Block *proto.Block
+	// Message describes the problem.
+	Message string
+	// Suggestion is the text suggested to fix the problem, if any.
+	Suggestion string
+}
+
+// NewBlock returns the *proto.Block ranging from pos to end.
+func NewBlock(fset *token.FileSet, pos, end token.Pos) *proto.Block {
+	sp := fset.Position(pos)
+	ep := fset.Position(end)
+
+	return &proto.Block{
+		StartLine:   safeIntToUint32(sp.Line),
+		StartColumn: safeIntToUint32(sp.Column),
+		EndLine:     safeIntToUint32(ep.Line),
+		EndColumn:   safeIntToUint32(ep.Column),
+	}
+}
+
+// HeaderCommentGroups returns the *ast.CommentGroup belonging to the given *proto.Block as HeaderComments.
+func HeaderCommentGroups(fset *token.FileSet, f *ast.File, b *proto.Block) []*ast.CommentGroup {
+	cgs := []*ast.CommentGroup{}
+	for _, cg := range f.Comments {
+		if IsHeader(fset, cg, b) && IsDocumentation(cg.Text()) {
+			cgs = append(cgs, cg)
+		}
+	}
+
+	return cgs
+}
+
+// isDocumented returns true if the CoverageItem has HeaderComments to be checked by the semantic checks.
+// The undocumented ones are not checked since they are reported by the coverage itself.
+func isDocumented(ci *proto.CoverageItem) bool {
+	return len(ci.HeaderComments) > 0
+}
+
+// FindInCommentGroup returns the *proto.Block of the first occurrence of str in the raw comments of cg.
+// It returns the *proto.Block of the whole cg if str is not found.
+func FindInCommentGroup(fset *token.FileSet, cg *ast.CommentGroup, str string) *proto.Block {
+	for _, c := range cg.List {
+		if i := strings.Index(c.Text, str); i >= 0 {
+			pos := c.Slash + token.Pos(i)
+			return NewBlock(fset, pos, pos+token.Pos(len(str)))
+		}
+	}
+
+	return NewBlock(fset, cg.Pos(), cg.End())
+}
+
+// FindWordInCommentGroup returns the *proto.Block of the first occurrence of word as a whole word in the raw comments of cg.
+// It returns the *proto.Block of the whole cg if word is not found.
+func FindWordInCommentGroup(fset *token.FileSet, cg *ast.CommentGroup, word string) *proto.Block {
+	re := regexp.MustCompile(`(?:^|[^\pL\pN_])(` + regexp.QuoteMeta(word) + `)(?:$|[^\pL\pN_])`)
+	for _, c := range cg.List {
+		if m := re.FindStringSubmatchIndex(c.Text); m != nil {
+			pos := c.Slash + token.Pos(m[2])
+			return NewBlock(fset, pos, pos+token.Pos(len(word)))
+		}
+	}
+
+	return NewBlock(fset, cg.Pos(), cg.End())
+}
@@ -0,0 +1,205 @@
+package ast
+
+import (
+	"fmt"
+	"go/ast"
+	"go/doc/comment"
+	"go/token"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/commentcov/commentcov/proto"
+)
+
+// DocLinkCheck is the name of the check for the doc links.
+const DocLinkCheck = "doc_link"
+
+// packageLinkRe matches the doc link syntax referring to a package, like [pkg.Name] or [pkg.Type.Method].
+// The name following the package has to be exported, so that the file names like [config.yaml] are not matched.
+var packageLinkRe = regexp.MustCompile(`\[\*?[a-z_]\w*\.[A-Z]\w*(\.[A-Za-z_]\w*)?\]`)
+
+// CheckDocLinks reports the doc links in the header comments of the CoverageItem which do not resolve
+// against the declarations of the package and the package names imported by the file.
+// The unexported single word links like [optional] are regarded as prose unless declared.
+func CheckDocLinks(file string, fset *token.FileSet, f *ast.File, pkg *Package, ci *proto.CoverageItem) []*Diagnostic {
+	imports := ImportNames(f)
+
+	p := comment.Parser{
+		LookupPackage: func(name string) (string, bool) {
+			importPath, ok := imports[name]
+			return importPath, ok
+		},
+		LookupSym: func(_, _ string) bool {
+			return true
+		},
+	}
+
+	ds := []*Diagnostic{}
+	for _, cg := range HeaderCommentGroups(fset, f, ci.TargetBlock) {
+		doc := p.Parse(cg.Text())
+
+		for _, link := range DocLinks(doc) {
+			if link.ImportPath != "" {
+				continue
+			}
+
+			if link.Recv == "" && !pkg.HasSymbol("", link.Name) && !token.IsExported(link.Name) {
+				continue
+			}
+
+			if pkg.HasSymbol(link.Recv, link.Name) {
+				continue
+			}
+
+			text := "[" + plainText(link.Text) + "]"
+			ds = append(ds, &Diagnostic{
+				Check:      DocLinkCheck,
+				File:       file,
+				Identifier: ci.Identifier,
+				Block:      FindInCommentGroup(fset, cg, text),
+				Message:    fmt.Sprintf("doc link %s does not resolve to any declaration", text),
+			})
+		}
+
+		// the links to the packages neither imported nor in the standard library are left as plain text by the parser.
+		for _, text := range packageLinkRe.FindAllString(strings.Join(docPlainTexts(doc), "\n"), -1) {
+			ds = append(ds, &Diagnostic{
+				Check:      DocLinkCheck,
+				File:       file,
+				Identifier: ci.Identifier,
+				Block:      FindInCommentGroup(fset, cg, text),
+				Message:    fmt.Sprintf("doc link %s refers to a package not imported", text),
+			})
+		}
+	}
+
+	return ds
+}
+
+// ImportNames returns the package names imported by the file and their import paths.
+// The package name of an import without alias is assumed to be the last element of the import path.
+func ImportNames(f *ast.File) map[string]string {
+	names := map[string]string{}
+	for _, imp := range f.Imports {
+		importPath, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		name := path.Base(importPath)
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+
+		if name == "_" || name == "." {
+			continue
+		}
+
+		names[name] = importPath
+	}
+
+	return names
+}
+
+// DocLinks returns the doc links in the given *comment.Doc.
+func DocLinks(doc *comment.Doc) []*comment.DocLink {
+	links := []*comment.DocLink{}
+	for _, b := range doc.Content {
+		links = append(links, blockDocLinks(b)...)
+	}
+
+	return links
+}
+
+// blockDocLinks returns the doc links in the given comment.Block.
+func blockDocLinks(b comment.Block) []*comment.DocLink {
+	links := []*comment.DocLink{}
+	switch blk := b.(type) {
+	case *comment.Paragraph:
+		links = append(links, textDocLinks(blk.Text)...)
+
+	case *comment.Heading:
+		links = append(links, textDocLinks(blk.Text)...)
+
+	case *comment.List:
+		for _, item := range blk.Items {
+			for _, c := range item.Content {
+				links = append(links, blockDocLinks(c)...)
+			}
+		}
+	}
+
+	return links
+}
+
+// textDocLinks returns the doc links in the given comment.Text.
+func textDocLinks(ts []comment.Text) []*comment.DocLink {
+	links := []*comment.DocLink{}
+	for _, t := range ts {
+		if link, ok := t.(*comment.DocLink); ok {
+			links = append(links, link)
+		}
+	}
+
+	return links
+}
+
+// docPlainTexts returns the plain texts, which are not links, in the paragraphs, the headings and the list items of the given *comment.Doc.
+func docPlainTexts(doc *comment.Doc) []string {
+	texts := []string{}
+	for _, b := range doc.Content {
+		texts = append(texts, blockPlainTexts(b)...)
+	}
+
+	return texts
+}
+
+// blockPlainTexts returns the plain texts in the given comment.Block.
+func blockPlainTexts(b comment.Block) []string {
+	texts := []string{}
+	switch blk := b.(type) {
+	case *comment.Paragraph:
+		for _, t := range blk.Text {
+			if p, ok := t.(comment.Plain); ok {
+				texts = append(texts, string(p))
+			}
+		}
+
+	case *comment.Heading:
+		for _, t := range blk.Text {
+			if p, ok := t.(comment.Plain); ok {
+				texts = append(texts, string(p))
+			}
+		}
+
+	case *comment.List:
+		for _, item := range blk.Items {
+			for _, c := range item.Content {
+				texts = append(texts, blockPlainTexts(c)...)
+			}
+		}
+	}
+
+	return texts
+}
+
+// plainText returns the text without any markup.
+func plainText(ts []comment.Text) string {
+	var sb strings.Builder
+	for _, t := range ts {
+		switch txt := t.(type) {
+		case comment.Plain:
+			sb.WriteString(string(txt))
+		case comment.Italic:
+			sb.WriteString(string(txt))
+		case *comment.Link:
+			sb.WriteString(plainText(txt.Text))
+		case *comment.DocLink:
+			sb.WriteString(plainText(txt.Text))
+		}
+	}
+
+	return sb.String()
+}
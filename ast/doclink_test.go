@@ -0,0 +1,138 @@
+package ast_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/commentcov/commentcov/proto"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	myAst "github.com/commentcov/commentcov-plugin-go/ast"
+)
+
+// TestCheckDocLinks is the unittest for CheckDocLinks.
+//
+//nolint:funlen
+func TestCheckDocLinks(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []*myAst.Diagnostic
+	}{
+		{
+			name: "resolved links",
+			src: `package hoge
+
+import (
+    "fmt"
+    str "strings"
+)
+
+// MyFunc returns [MyType] made by [NewMyType] and calls [MyType.Method], [MyType.Field],
+// [fmt.Println] and [str.Join].
+func MyFunc() {}
+
+type MyType struct {
+    Field string
+}
+
+func NewMyType() *MyType { return nil }
+
+func (m *MyType) Method() {}
+`,
+			want: []*myAst.Diagnostic{},
+		},
+		{
+			name: "broken links",
+			src: `package hoge
+
+// MyFunc returns [OldType] and calls [MyType.OldMethod] and [oldpkg.Func].
+func MyFunc() {}
+
+type MyType struct{}
+`,
+			want: []*myAst.Diagnostic{
+				{
+					Check:      myAst.DocLinkCheck,
+					File:       "hoge.go",
+					Identifier: "MyFunc",
+					Block: &proto.Block{
+						StartLine:   3,
+						StartColumn: 19,
+						EndLine:     3,
+						EndColumn:   28,
+					},
+					Message: "doc link [OldType] does not resolve to any declaration",
+				},
+				{
+					Check:      myAst.DocLinkCheck,
+					File:       "hoge.go",
+					Identifier: "MyFunc",
+					Block: &proto.Block{
+						StartLine:   3,
+						StartColumn: 39,
+						EndLine:     3,
+						EndColumn:   57,
+					},
+					Message: "doc link [MyType.OldMethod] does not resolve to any declaration",
+				},
+				{
+					Check:      myAst.DocLinkCheck,
+					File:       "hoge.go",
+					Identifier: "MyFunc",
+					Block: &proto.Block{
+						StartLine:   3,
+						StartColumn: 62,
+						EndLine:     3,
+						EndColumn:   75,
+					},
+					Message: "doc link [oldpkg.Func] refers to a package not imported",
+				},
+			},
+		},
+		{
+			name: "unexported single word is prose",
+			src: `package hoge
+
+// MyFunc takes [optional] arguments.
+func MyFunc() {}
+`,
+			want: []*myAst.Diagnostic{},
+		},
+		{
+			name: "file names are prose",
+			src: `package hoge
+
+// MyFunc reads [config.yaml], [main.go] and [go.mod].
+func MyFunc() {}
+`,
+			want: []*myAst.Diagnostic{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			f, err := parser.ParseFile(fset, "hoge.go", tt.src, parser.ParseComments)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pkg := myAst.NewPackage("hoge", f)
+
+			got := []*myAst.Diagnostic{}
+			for _, decl := range f.Decls {
+				if d, ok := decl.(*ast.FuncDecl); ok && d.Name.Name == "MyFunc" {
+					ci := myAst.ProcessFunctionCoverage("hoge.go", fset, f, d)
+					got = append(got, myAst.CheckDocLinks("hoge.go", fset, f, pkg, ci)...)
+				}
+			}
+
+			if diff := cmp.Diff(tt.want, got, cmpopts.IgnoreUnexported(proto.Block{})); diff != "" {
+				t.Errorf("Diagnostic values are mismatch (-want +got):%s\n", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package ast
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/commentcov/commentcov/proto"
+)
+
+// nonWordRe matches the runs of the characters other than letters and digits.
+var nonWordRe = regexp.MustCompile(`[^\pL\pN]+`)
+
+// identifierPlaceholder replaces the identifier of the CoverageItem in DuplicateKey.
+const identifierPlaceholder = "_"
+
+// DuplicateCluster is a set of the CoverageItems sharing the identical or near-identical HeaderComments.
+type DuplicateCluster struct {
+	// Key is the DuplicateKey shared by the Items.
+	Key string
+	// Exact is true if the HeaderComments of the Items are identical after Normalize.
+	Exact bool
+	// Items are the CoverageItems in the cluster in the given order.
+	Items []*proto.CoverageItem
+}
+
+// DuplicateKey returns the key to find the near-identical HeaderComments of the given CoverageItem.
+// The comments are regarded as near-identical if they differ only in the case, the punctuation, the whitespace
+// and the identifier of the CoverageItem, like "Get returns the value." and "GetName returns the value".
+// It returns "" if the CoverageItem has no HeaderComments.
+func DuplicateKey(ci *proto.CoverageItem) string {
+	base := BaseIdentifier(ci.Identifier)
+	text := commentsText(ci.HeaderComments)
+	// the key is logged as the comment of the cluster.
+	text = RedactSecrets(text, FindSecrets(text))
+	words := strings.Fields(nonWordRe.ReplaceAllString(text, " "))
+	for i, w := range words {
+		if w == base {
+			words[i] = identifierPlaceholder
+			continue
+		}
+
+		words[i] = strings.ToLower(w)
+	}
+
+	return strings.Join(words, " ")
+}
+
+// FindDuplicates returns the clusters of the CoverageItems which share the DuplicateKey, in the order of the first Items.
+// The clusters of a single CoverageItem are omitted.
+// The CoverageItems sharing the same comments, like the specs attributed the doc comment of their group by AttributionGroup,
+// are clustered as the first one only, since the comments are not the copies.
+func FindDuplicates(items []*proto.CoverageItem) []*DuplicateCluster {
+	clusters := map[string]*DuplicateCluster{}
+	keys := []string{}
+	sources := map[string]bool{}
+
+	for _, ci := range items {
+		key := DuplicateKey(ci)
+		if key == "" {
+			continue
+		}
+
+		if source := commentsSource(ci); source != "" {
+			if sources[source] {
+				continue
+			}
+			sources[source] = true
+		}
+
+		c, ok := clusters[key]
+		if !ok {
+			c = &DuplicateCluster{
+				Key:   key,
+				Exact: true,
+				Items: []*proto.CoverageItem{},
+			}
+			clusters[key] = c
+			keys = append(keys, key)
+		}
+
+		if len(c.Items) > 0 && commentsText(c.Items[0].HeaderComments) != commentsText(ci.HeaderComments) {
+			c.Exact = false
+		}
+		c.Items = append(c.Items, ci)
+	}
+
+	dups := []*DuplicateCluster{}
+	for _, key := range keys {
+		if len(clusters[key].Items) > 1 {
+			dups = append(dups, clusters[key])
+		}
+	}
+
+	return dups
+}
+
+// commentsSource returns the file and the positions of the HeaderComments of the CoverageItem.
+// It returns "" if any of the positions is unknown.
+func commentsSource(ci *proto.CoverageItem) string {
+	source := ci.File
+	for _, c := range ci.HeaderComments {
+		if c.Block == nil {
+			return ""
+		}
+
+		source += fmt.Sprintf(":%d:%d", c.Block.StartLine, c.Block.StartColumn)
+	}
+
+	return source
+}
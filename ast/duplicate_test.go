@@ -0,0 +1,104 @@
+package ast_test
+
+import (
+	"testing"
+
+	"github.com/commentcov/commentcov/proto"
+	"github.com/google/go-cmp/cmp"
+
+	myAst "github.com/commentcov/commentcov-plugin-go/ast"
+)
+
+// TestFindDuplicates is the unittest for FindDuplicates.
+//
+//nolint:funlen
+func TestFindDuplicates(t *testing.T) {
+	item := func(identifier, text string) *proto.CoverageItem {
+		ci := &proto.CoverageItem{
+			Identifier:     identifier,
+			HeaderComments: []*proto.Comment{},
+		}
+		if text != "" {
+			ci.HeaderComments = append(ci.HeaderComments, &proto.Comment{Comment: text})
+		}
+
+		return ci
+	}
+
+	getA := item("Get", "Get returns the value.\n")
+	getB := item("Get", "Get returns the value.\n")
+	getName := item("GetName", "GetName returns  the Value\n")
+	set := item("Set", "Set stores the value.\n")
+	undocumented := item("Del", "")
+	// the specs attributed the doc comment of their group share the same comment.
+	grouped := func(identifier string) *proto.CoverageItem {
+		ci := item(identifier, "Grouped constants.\n")
+		ci.File = "hoge.go"
+		ci.HeaderComments[0].Block = &proto.Block{StartLine: 3, StartColumn: 1, EndLine: 3, EndColumn: 22}
+
+		return ci
+	}
+	groupedA := grouped("A")
+	groupedB := grouped("B")
+	copied := item("C", "Grouped constants.\n")
+	undocumented2 := item("Del", "")
+
+	tests := []struct {
+		name  string
+		items []*proto.CoverageItem
+		want  []*myAst.DuplicateCluster
+	}{
+		{
+			name:  "identical",
+			items: []*proto.CoverageItem{getA, set, getB, undocumented, undocumented2},
+			want: []*myAst.DuplicateCluster{
+				{
+					Key:   "_ returns the value",
+					Exact: true,
+					Items: []*proto.CoverageItem{getA, getB},
+				},
+			},
+		},
+		{
+			name:  "near-identical",
+			items: []*proto.CoverageItem{getA, getName, set},
+			want: []*myAst.DuplicateCluster{
+				{
+					Key:   "_ returns the value",
+					Exact: false,
+					Items: []*proto.CoverageItem{getA, getName},
+				},
+			},
+		},
+		{
+			name:  "group attribution",
+			items: []*proto.CoverageItem{groupedA, groupedB},
+			want:  []*myAst.DuplicateCluster{},
+		},
+		{
+			name:  "copy of the group doc",
+			items: []*proto.CoverageItem{groupedA, groupedB, copied},
+			want: []*myAst.DuplicateCluster{
+				{
+					Key:   "grouped constants",
+					Exact: true,
+					Items: []*proto.CoverageItem{groupedA, copied},
+				},
+			},
+		},
+		{
+			name:  "no duplicates",
+			items: []*proto.CoverageItem{getA, set, undocumented},
+			want:  []*myAst.DuplicateCluster{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := myAst.FindDuplicates(tt.items)
+			if diff := cmp.Diff(tt.want, got, coverageItemCmp); diff != "" {
+				t.Errorf("DuplicateCluster values are mismatch (-want +got):%s\n", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package ast
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/commentcov/commentcov/proto"
+)
+
+// ErrorDocCheck is the name of the check for the documentation of the returned errors.
+const ErrorDocCheck = "error_doc"
+
+// errorWordRe matches the words describing errors, and "returns" describing what the function returns.
+var errorWordRe = regexp.MustCompile(`(?i)\b(errors?|fails?|failures?|failed|returns)\b`)
+
+// CheckErrorDoc reports the exported function whose last result is error but whose header comments
+// describe neither the errors nor the sentinel errors, named Err*, which the function body returns.
+func CheckErrorDoc(file string, fset *token.FileSet, fdecl *ast.FuncDecl, ci *proto.CoverageItem) []*Diagnostic {
+	if !ast.IsExported(fdecl.Name.Name) || !isDocumented(ci) || !ReturnsError(fdecl) {
+		return []*Diagnostic{}
+	}
+
+	text := commentsText(ci.HeaderComments)
+	sentinels := ReturnedSentinelErrors(fdecl)
+
+	missing := []string{}
+	for _, s := range sentinels {
+		if !IsMentioned(text, s) {
+			missing = append(missing, s)
+		}
+	}
+
+	var msg string
+	switch {
+	case len(missing) > 0:
+		msg = fmt.Sprintf("comment does not describe the returned errors %s", strings.Join(missing, ", "))
+	case len(sentinels) == 0 && !errorWordRe.MatchString(text):
+		msg = "comment does not describe when or what errors are returned"
+	default:
+		return []*Diagnostic{}
+	}
+
+	return []*Diagnostic{
+		{
+			Check:      ErrorDocCheck,
+			File:       file,
+			Identifier: ci.Identifier,
+			Block:      NewBlock(fset, fdecl.Name.Pos(), fdecl.Name.End()),
+			Message:    msg,
+		},
+	}
+}
+
+// ReturnsError returns true if the last result of the function is error.
+func ReturnsError(fdecl *ast.FuncDecl) bool {
+	results := fdecl.Type.Results
+	if results == nil || len(results.List) == 0 {
+		return false
+	}
+
+	ident, ok := results.List[len(results.List)-1].Type.(*ast.Ident)
+	return ok && ident.Name == "error"
+}
+
+// ReturnedSentinelErrors returns the sentinel errors, the identifiers named Err*, in the return statements of the function,
+// including the ones wrapped like fmt.Errorf("...: %w", ErrNotFound).
+// The ones of other packages are qualified with the package name.
+func ReturnedSentinelErrors(fdecl *ast.FuncDecl) []string {
+	sentinels := []string{}
+	if fdecl.Body == nil {
+		return sentinels
+	}
+
+	ast.Inspect(fdecl.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			// the returns of the closures are not the ones of the function.
+			return false
+
+		case *ast.ReturnStmt:
+			if len(node.Results) == 0 {
+				return false
+			}
+
+			ast.Inspect(node.Results[len(node.Results)-1], func(n ast.Node) bool {
+				name := sentinelName(n)
+				if name == "" {
+					return true
+				}
+
+				if !slices.Contains(sentinels, name) {
+					sentinels = append(sentinels, name)
+				}
+
+				return false
+			})
+
+			return false
+		}
+
+		return true
+	})
+
+	return sentinels
+}
+
+// sentinelName returns the name of the sentinel error if the node refers to one.
+func sentinelName(n ast.Node) string {
+	switch node := n.(type) {
+	case *ast.Ident:
+		if isSentinelName(node.Name) {
+			return node.Name
+		}
+
+	case *ast.SelectorExpr:
+		if pkg, ok := node.X.(*ast.Ident); ok && isSentinelName(node.Sel.Name) {
+			return pkg.Name + "." + node.Sel.Name
+		}
+	}
+
+	return ""
+}
+
+// isSentinelName returns true if the name follows the naming convention of the sentinel errors.
+func isSentinelName(name string) bool {
+	return strings.HasPrefix(name, "Err") && len(name) > len("Err") && ast.IsExported(name[len("Err"):])
+}
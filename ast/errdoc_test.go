@@ -0,0 +1,129 @@
+package ast_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/commentcov/commentcov/proto"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	myAst "github.com/commentcov/commentcov-plugin-go/ast"
+)
+
+// TestCheckErrorDoc is the unittest for CheckErrorDoc.
+//
+//nolint:funlen
+func TestCheckErrorDoc(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []*myAst.Diagnostic
+	}{
+		{
+			name: "errors described",
+			src: `package hoge
+
+// MyFunc loads the file. It returns an error if the file is missing.
+func MyFunc() error {
+    return nil
+}
+`,
+			want: []*myAst.Diagnostic{},
+		},
+		{
+			name: "errors not described",
+			src: `package hoge
+
+// MyFunc loads the file.
+func MyFunc() (string, error) {
+    return "", nil
+}
+`,
+			want: []*myAst.Diagnostic{
+				{
+					Check:      myAst.ErrorDocCheck,
+					File:       "hoge.go",
+					Identifier: "MyFunc",
+					Block:      &proto.Block{StartLine: 4, StartColumn: 6, EndLine: 4, EndColumn: 12},
+					Message:    "comment does not describe when or what errors are returned",
+				},
+			},
+		},
+		{
+			name: "returns described",
+			src: `package hoge
+
+// MyFunc returns the content of the file.
+func MyFunc() (string, error) {
+    return "", nil
+}
+`,
+			want: []*myAst.Diagnostic{},
+		},
+		{
+			name: "sentinel errors partially described",
+			src: `package hoge
+
+// MyFunc loads the file. It returns ErrNotFound if the file is missing.
+func MyFunc() error {
+    if missing {
+        return ErrNotFound
+    }
+    if denied {
+        return fmt.Errorf("load: %w", fs.ErrPermission)
+    }
+    go func() error { return ErrIgnored }()
+    return ErrNotFound
+}
+`,
+			want: []*myAst.Diagnostic{
+				{
+					Check:      myAst.ErrorDocCheck,
+					File:       "hoge.go",
+					Identifier: "MyFunc",
+					Block:      &proto.Block{StartLine: 4, StartColumn: 6, EndLine: 4, EndColumn: 12},
+					Message:    "comment does not describe the returned errors fs.ErrPermission",
+				},
+			},
+		},
+		{
+			name: "not checked",
+			src: `package hoge
+
+// myFunc loads the file.
+func myFunc() error { return nil }
+
+func NoComment() error { return nil }
+
+// NoError loads the file.
+func NoError() string { return "" }
+`,
+			want: []*myAst.Diagnostic{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			f, err := parser.ParseFile(fset, "hoge.go", tt.src, parser.ParseComments)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := []*myAst.Diagnostic{}
+			for _, decl := range f.Decls {
+				if d, ok := decl.(*ast.FuncDecl); ok {
+					ci := myAst.ProcessFunctionCoverage("hoge.go", fset, f, d)
+					got = append(got, myAst.CheckErrorDoc("hoge.go", fset, d, ci)...)
+				}
+			}
+
+			if diff := cmp.Diff(tt.want, got, cmpopts.IgnoreUnexported(proto.Block{})); diff != "" {
+				t.Errorf("Diagnostic values are mismatch (-want +got):%s\n", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package ast
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// examplePrefix is the prefix of the example function names.
+const examplePrefix = "Example"
+
+// maxExampleTargetParts is the number of the parts of the example target, Type and Method.
+const maxExampleTargetParts = 2
+
+// ExampleTarget returns the identifier which the example function documents, following the godoc naming convention.
+// It returns "F" for ExampleF, "T.M" for ExampleT_M, and the empty string for the package example Example.
+// The lowercase suffix like ExampleF_suffix is ignored.
+// ok is false if the given name is not the name of an example function.
+func ExampleTarget(name string) (target string, ok bool) {
+	if !strings.HasPrefix(name, examplePrefix) {
+		return "", false
+	}
+
+	s := strings.TrimPrefix(name, examplePrefix)
+	if s == "" {
+		return "", true
+	}
+
+	if r, _ := utf8.DecodeRuneInString(s); unicode.IsLower(r) {
+		return "", false
+	}
+
+	parts := strings.Split(s, "_")
+	if last := parts[len(parts)-1]; len(parts) > 1 && isExampleSuffix(last) {
+		parts = parts[:len(parts)-1]
+	}
+
+	switch {
+	case parts[0] == "":
+		return "", len(parts) == 1
+	case len(parts) <= maxExampleTargetParts:
+		return strings.Join(parts, "."), true
+	}
+
+	return "", false
+}
+
+// isExampleSuffix returns true if the given part of the example function name is a suffix.
+func isExampleSuffix(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	return unicode.IsLower(r)
+}
+
+// IsExampleFunc returns true if the given function is a runnable example.
+func IsExampleFunc(fdecl *ast.FuncDecl) bool {
+	if fdecl.Recv != nil || fdecl.Type.Params.NumFields() > 0 || fdecl.Type.Results.NumFields() > 0 {
+		return false
+	}
+
+	_, ok := ExampleTarget(fdecl.Name.Name)
+	return ok
+}
+
+// LoadExamples parses the test files in dir for the package name, including its external test package,
+// and returns the names of the example functions keyed by their targets.
+func LoadExamples(fset *token.FileSet, dir, name string) (map[string][]string, error) {
+	files, err := parseGoFiles(fset, dir, IsTestFile)
+	if err != nil {
+		return nil, err
+	}
+
+	examples := map[string][]string{}
+	for _, f := range files {
+		if f.Name.Name != name && f.Name.Name != name+"_test" {
+			continue
+		}
+
+		for _, decl := range f.Decls {
+			fdecl, ok := decl.(*ast.FuncDecl)
+			if !ok || !IsExampleFunc(fdecl) {
+				continue
+			}
+
+			target, _ := ExampleTarget(fdecl.Name.Name)
+			examples[target] = append(examples[target], fdecl.Name.Name)
+		}
+	}
+
+	return examples, nil
+}
+
+// ExampleKey returns the key of the function in the examples returned by LoadExamples.
+func ExampleKey(fdecl *ast.FuncDecl) string {
+	if recv := ReceiverTypeName(fdecl); recv != "" {
+		return recv + "." + fdecl.Name.Name
+	}
+
+	return fdecl.Name.Name
+}
@@ -0,0 +1,85 @@
+package ast_test
+
+import (
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	myAst "github.com/commentcov/commentcov-plugin-go/ast"
+)
+
+// TestExampleTarget is the unittest for ExampleTarget.
+func TestExampleTarget(t *testing.T) {
+	tests := []struct {
+		name       string
+		funcName   string
+		wantTarget string
+		wantOk     bool
+	}{
+		{name: "package", funcName: "Example", wantTarget: "", wantOk: true},
+		{name: "package with suffix", funcName: "Example_suffix", wantTarget: "", wantOk: true},
+		{name: "function", funcName: "ExampleFoo", wantTarget: "Foo", wantOk: true},
+		{name: "function with suffix", funcName: "ExampleFoo_suffix", wantTarget: "Foo", wantOk: true},
+		{name: "method", funcName: "ExampleBar_Method", wantTarget: "Bar.Method", wantOk: true},
+		{name: "method with suffix", funcName: "ExampleBar_Method_suffix", wantTarget: "Bar.Method", wantOk: true},
+		{name: "lowercase after prefix", funcName: "Examplefoo", wantTarget: "", wantOk: false},
+		{name: "too many parts", funcName: "ExampleA_B_C", wantTarget: "", wantOk: false},
+		{name: "not an example", funcName: "TestFoo", wantTarget: "", wantOk: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, ok := myAst.ExampleTarget(tt.funcName)
+			if diff := cmp.Diff(tt.wantTarget, target); diff != "" {
+				t.Errorf("string values are mismatch (-want +got):%s\n", diff)
+			}
+			if diff := cmp.Diff(tt.wantOk, ok); diff != "" {
+				t.Errorf("bool values are mismatch (-want +got):%s\n", diff)
+			}
+		})
+	}
+}
+
+// TestLoadExamples is the unittest for LoadExamples.
+func TestLoadExamples(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"hoge.go": "package hoge\n\nfunc Example() {}\n",
+		"hoge_test.go": `package hoge
+
+func Example() {}
+
+func ExampleFoo() {}
+
+func ExampleFoo_second() {}
+
+func ExampleWithArg(t int) {}
+
+func TestFoo(t *testing.T) {}
+`,
+		"external_test.go": "package hoge_test\n\nfunc ExampleBar_Method() {}\n",
+		"other_test.go":    "package fuga\n\nfunc ExampleBaz() {}\n",
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := myAst.LoadExamples(token.NewFileSet(), dir, "hoge")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string][]string{
+		"":           {"Example"},
+		"Foo":        {"ExampleFoo", "ExampleFoo_second"},
+		"Bar.Method": {"ExampleBar_Method"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("example values are mismatch (-want +got):%s\n", diff)
+	}
+}
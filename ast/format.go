@@ -0,0 +1,173 @@
+package ast
+
+import (
+	"fmt"
+	"go/ast"
+	"go/doc/comment"
+	"go/token"
+	"regexp"
+	"strings"
+
+	"github.com/commentcov/commentcov/proto"
+)
+
+// DocFormatCheck is the name of the check for the doc comment formatting.
+const DocFormatCheck = "doc_format"
+
+// directiveRe matches the directive comments like //go:generate or //nolint:lll, which gofmt keeps as they are.
+var directiveRe = regexp.MustCompile(`^//(line |extern |export |[a-z0-9]+:[a-z0-9])`)
+
+// CheckDocFormat reports the header comments of the CoverageItem which gofmt would rewrite,
+// suggesting the reformatted comment, and the ones rendered badly by godoc,
+// which are the accidental code blocks from the indentation and the headings detected implicitly.
+// Only the doc comments of the package clause and the top-level declarations starting in column 1 are checked against gofmt,
+// since it leaves the others, like the ones in `const ( ... )` and on the fields, as they are.
+// The comments written with /* */ are not checked since gofmt does not reformat them.
+func CheckDocFormat(file string, fset *token.FileSet, f *ast.File, ci *proto.CoverageItem) []*Diagnostic {
+	ds := []*Diagnostic{}
+	for _, cg := range HeaderCommentGroups(fset, f, ci.TargetBlock) {
+		lines, ok := docCommentLines(cg)
+		if !ok {
+			continue
+		}
+
+		var p comment.Parser
+		doc := p.Parse(cg.Text())
+		block := NewBlock(fset, cg.Pos(), cg.End())
+
+		formatted := FormatDocComment(doc)
+		if isTopLevelDoc(fset, f, cg) && strings.Join(lines, "\n") != formatted {
+			ds = append(ds, &Diagnostic{
+				Check:      DocFormatCheck,
+				File:       file,
+				Identifier: ci.Identifier,
+				Block:      block,
+				Message:    "comment would be reformatted by gofmt",
+				Suggestion: formatted,
+			})
+		}
+
+		for _, msg := range renderingProblems(doc, lines) {
+			ds = append(ds, &Diagnostic{
+				Check:      DocFormatCheck,
+				File:       file,
+				Identifier: ci.Identifier,
+				Block:      block,
+				Message:    msg,
+			})
+		}
+	}
+
+	return ds
+}
+
+// isTopLevelDoc returns true if cg is the doc comment of the package clause or of a top-level declaration,
+// starting in column 1.
+func isTopLevelDoc(fset *token.FileSet, f *ast.File, cg *ast.CommentGroup) bool {
+	if fset.Position(cg.Pos()).Column != 1 {
+		return false
+	}
+
+	if f.Doc == cg {
+		return true
+	}
+
+	for _, decl := range f.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Doc == cg {
+				return true
+			}
+		case *ast.GenDecl:
+			if d.Doc == cg {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// docCommentLines returns the lines of the // comments in cg except the directives and the trailing blank lines.
+// ok is false if cg contains /* */ comments.
+func docCommentLines(cg *ast.CommentGroup) (lines []string, ok bool) {
+	lines = []string{}
+	for _, c := range cg.List {
+		if strings.HasPrefix(c.Text, "/*") {
+			return nil, false
+		}
+
+		if directiveRe.MatchString(c.Text) {
+			continue
+		}
+
+		lines = append(lines, c.Text)
+	}
+
+	// the blank line separating the directives is kept by gofmt.
+	for len(lines) > 0 && strings.TrimRight(lines[len(lines)-1], " \t") == "//" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines, true
+}
+
+// FormatDocComment returns the // comment lines of the *comment.Doc formatted as gofmt does.
+func FormatDocComment(doc *comment.Doc) string {
+	var pr comment.Printer
+	text := strings.TrimSuffix(string(pr.Comment(doc)), "\n")
+
+	lines := []string{}
+	for _, l := range strings.Split(text, "\n") {
+		switch {
+		case l == "":
+			lines = append(lines, "//")
+		case strings.HasPrefix(l, "\t"):
+			lines = append(lines, "//"+l)
+		default:
+			lines = append(lines, "// "+l)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// renderingProblems returns the messages describing the blocks of the *comment.Doc rendered unexpectedly.
+// lines are the raw comment lines of the Doc.
+func renderingProblems(doc *comment.Doc, lines []string) []string {
+	msgs := []string{}
+	for _, b := range doc.Content {
+		switch blk := b.(type) {
+		case *comment.Code:
+			text := strings.TrimSpace(blk.Text)
+			if !strings.Contains(text, "\n") && isSentence(text) {
+				msgs = append(msgs, fmt.Sprintf("indented sentence %q is rendered as a code block", text))
+			}
+
+		case *comment.Heading:
+			text := plainText(blk.Text)
+			if !containsLine(lines, "// # "+text) {
+				msgs = append(msgs, fmt.Sprintf("line %q is rendered as a heading, write it as \"# %s\" if intended", text, text))
+			}
+		}
+	}
+
+	return msgs
+}
+
+// isSentence returns true if the text looks like an English sentence rather than code.
+func isSentence(text string) bool {
+	words := strings.Fields(text)
+	return len(words) > 1 && strings.HasSuffix(text, ".") && !IsCommentedOutCode(text)
+}
+
+// containsLine returns true if lines contains the line ignoring the trailing spaces.
+func containsLine(lines []string, line string) bool {
+	for _, l := range lines {
+		if strings.TrimRight(l, " \t") == line {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,174 @@
+package ast_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/commentcov/commentcov/proto"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	myAst "github.com/commentcov/commentcov-plugin-go/ast"
+)
+
+// TestCheckDocFormat is the unittest for CheckDocFormat.
+//
+//nolint:funlen
+func TestCheckDocFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []*myAst.Diagnostic
+	}{
+		{
+			name: "formatted comment",
+			src: `package hoge
+
+// MyFunc does something.
+//
+// # Usage
+//
+//	MyFunc()
+//
+//nolint:funlen
+func MyFunc() {}
+`,
+			want: []*myAst.Diagnostic{},
+		},
+		{
+			name: "block comment is not checked",
+			src: `package hoge
+
+/*
+MyFunc does something.
+    MyFunc()
+*/
+func MyFunc() {}
+`,
+			want: []*myAst.Diagnostic{},
+		},
+		{
+			name: "code block indented by spaces",
+			src: `package hoge
+
+// MyFunc does something.
+//
+//    MyFunc()
+func MyFunc() {}
+`,
+			want: []*myAst.Diagnostic{
+				{
+					Check:      myAst.DocFormatCheck,
+					File:       "hoge.go",
+					Identifier: "MyFunc",
+					Block:      &proto.Block{StartLine: 3, StartColumn: 1, EndLine: 5, EndColumn: 15},
+					Message:    "comment would be reformatted by gofmt",
+					Suggestion: "// MyFunc does something.\n//\n//\tMyFunc()",
+				},
+			},
+		},
+		{
+			name: "accidental code block and implicit heading",
+			src: `package hoge
+
+// MyFunc does something.
+//  It is indented accidentally.
+//
+// Usage
+//
+// Call it.
+func MyFunc() {}
+`,
+			want: []*myAst.Diagnostic{
+				{
+					Check:      myAst.DocFormatCheck,
+					File:       "hoge.go",
+					Identifier: "MyFunc",
+					Block:      &proto.Block{StartLine: 3, StartColumn: 1, EndLine: 8, EndColumn: 12},
+					Message:    "comment would be reformatted by gofmt",
+					Suggestion: "// MyFunc does something.\n//\n//\tIt is indented accidentally.\n//\n// # Usage\n//\n// Call it.",
+				},
+				{
+					Check:      myAst.DocFormatCheck,
+					File:       "hoge.go",
+					Identifier: "MyFunc",
+					Block:      &proto.Block{StartLine: 3, StartColumn: 1, EndLine: 8, EndColumn: 12},
+					Message:    `indented sentence "It is indented accidentally." is rendered as a code block`,
+				},
+				{
+					Check:      myAst.DocFormatCheck,
+					File:       "hoge.go",
+					Identifier: "MyFunc",
+					Block:      &proto.Block{StartLine: 3, StartColumn: 1, EndLine: 8, EndColumn: 12},
+					Message:    `line "Usage" is rendered as a heading, write it as "# Usage" if intended`,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			f, err := parser.ParseFile(fset, "hoge.go", tt.src, parser.ParseComments)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := []*myAst.Diagnostic{}
+			for _, decl := range f.Decls {
+				if d, ok := decl.(*ast.FuncDecl); ok {
+					ci := myAst.ProcessFunctionCoverage("hoge.go", fset, f, d)
+					got = append(got, myAst.CheckDocFormat("hoge.go", fset, f, ci)...)
+				}
+			}
+
+			if diff := cmp.Diff(tt.want, got, cmpopts.IgnoreUnexported(proto.Block{})); diff != "" {
+				t.Errorf("Diagnostic values are mismatch (-want +got):%s\n", diff)
+			}
+		})
+	}
+}
+
+// TestCheckDocFormat_Nested is the unittest for CheckDocFormat of the comments which gofmt does not reformat.
+func TestCheckDocFormat_Nested(t *testing.T) {
+	src := `package hoge
+
+//T is a type.
+type T struct {
+	//B is b.
+	B int
+}
+
+const (
+	//A is a.
+	A = 1
+)
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "hoge.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := []string{}
+	for _, decl := range f.Decls {
+		gdecl := decl.(*ast.GenDecl)
+		cis := myAst.ProcessGenDeclCoverage("hoge.go", fset, f, gdecl)
+		for _, ts := range myAst.TypeSpecs(gdecl) {
+			cis = append(cis, myAst.ProcessFieldCoverage("hoge.go", fset, f, ts)...)
+		}
+
+		for _, ci := range cis {
+			for _, d := range myAst.CheckDocFormat("hoge.go", fset, f, ci) {
+				got = append(got, d.Identifier)
+			}
+		}
+	}
+
+	// only the top-level doc comment is reformatted by gofmt.
+	if diff := cmp.Diff([]string{"T"}, got); diff != "" {
+		t.Errorf("Identifier values are mismatch (-want +got):%s\n", diff)
+	}
+}
@@ -0,0 +1,106 @@
+//go:build ignore
+
+// gen_words.go generates words.txt, the built-in English word list for the spell checking.
+// It is the general English vocabulary in english.txt, and the words collected from the doc comments
+// of the Go standard library on top of it, so that the technical terms used in Go projects are covered as well.
+// The words of the standard library appearing less than minOccurrences times are dropped to exclude the typos.
+package main
+
+import (
+	"bufio"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// minOccurrences is the minimum number of the occurrences for a word of the standard library to be listed.
+const minOccurrences = 2
+
+// baseFile is the general English vocabulary, a word per line, which is maintained by hand.
+const baseFile = "english.txt"
+
+// wordRe matches the words in lowercase or capitalized, including the contractions like doesn't.
+var wordRe = regexp.MustCompile(`\b[A-Za-z][a-z]*(?:'[a-z]+)?\b`)
+
+func main() {
+	base, err := os.ReadFile(baseFile)
+	if err != nil {
+		panic(err)
+	}
+
+	listed := map[string]bool{}
+	for _, w := range strings.Fields(string(base)) {
+		listed[strings.ToLower(w)] = true
+	}
+
+	counts := map[string]int{}
+
+	root := filepath.Join(runtime.GOROOT(), "src")
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() && (d.Name() == "testdata" || d.Name() == "vendor") {
+			return filepath.SkipDir
+		}
+
+		if d.IsDir() || !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+
+		f, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.ParseComments)
+		if err != nil {
+			return nil
+		}
+
+		ast.Inspect(f, func(n ast.Node) bool {
+			if cg, ok := n.(*ast.CommentGroup); ok {
+				for _, w := range wordRe.FindAllString(cg.Text(), -1) {
+					counts[strings.ToLower(w)]++
+				}
+			}
+
+			return true
+		})
+
+		return nil
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	for w, c := range counts {
+		if c >= minOccurrences && len(w) > 1 {
+			listed[w] = true
+		}
+	}
+
+	words := make([]string, 0, len(listed))
+	for w := range listed {
+		words = append(words, w)
+	}
+	sort.Strings(words)
+
+	out, err := os.Create("words.txt")
+	if err != nil {
+		panic(err)
+	}
+	defer out.Close()
+
+	bw := bufio.NewWriter(out)
+	for _, w := range words {
+		bw.WriteString(w + "\n")
+	}
+
+	if err := bw.Flush(); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,172 @@
+package ast
+
+import (
+	"go/ast"
+	"go/token"
+	"regexp"
+	"strings"
+
+	"github.com/commentcov/commentcov/proto"
+)
+
+// IgnoreCheck is the name of the check for the malformed ignore directives.
+const IgnoreCheck = "ignore"
+
+// The directives excluding the declarations and the files from the coverage.
+const (
+	// directivePrefix is the prefix of all the directives of the plugin.
+	directivePrefix = "//commentcov:"
+	// ignoreDirective excludes the declaration below it.
+	ignoreDirective = "ignore"
+	// ignoreFileDirective excludes the whole file. It has to be placed above the package clause.
+	ignoreFileDirective = "ignore-file"
+)
+
+// UnspecifiedReason is the reason of the Ignored excluded by the directives without reason="...".
+const UnspecifiedReason = "unspecified"
+
+// directiveSyntaxRe matches the directive of the plugin with its optional reason.
+var directiveSyntaxRe = regexp.MustCompile(`^//commentcov:([a-z-]+)(?:\s+reason="([^"]*)")?\s*$`)
+
+// Ignored is a CoverageItem excluded from the coverage by an ignore directive.
+type Ignored struct {
+	// Item is the CoverageItem excluded.
+	Item *proto.CoverageItem
+	// Reason is the reason given to the directive.
+	Reason string
+}
+
+// ignoreGroup is a comment group holding a valid ignore directive.
+type ignoreGroup struct {
+	cg     *ast.CommentGroup
+	reason string
+	// start and end are the lines of the parentheses of the grouped declaration the directive is attached to.
+	// They are 0 unless the directive is the doc comment of a grouped declaration.
+	start int
+	end   int
+}
+
+// Ignores holds the ignore directives of a file.
+type Ignores struct {
+	fset   *token.FileSet
+	file   *ignoreGroup
+	groups []*ignoreGroup
+	// Diagnostics are the malformed directives, which are not honored.
+	Diagnostics []*Diagnostic
+}
+
+// NewIgnores collects the ignore directives of the given file.
+// The directives without the reason, like `//commentcov:ignore`, are honored with UnspecifiedReason,
+// and the malformed and the unknown ones are reported as Diagnostics.
+func NewIgnores(file string, fset *token.FileSet, f *ast.File) *Ignores {
+	ig := &Ignores{
+		fset:        fset,
+		groups:      []*ignoreGroup{},
+		Diagnostics: []*Diagnostic{},
+	}
+
+	// the lines of the parentheses of the grouped declarations keyed by their doc comments.
+	parens := map[*ast.CommentGroup][2]int{}
+	for _, decl := range f.Decls {
+		if d, ok := decl.(*ast.GenDecl); ok && d.Doc != nil && d.Lparen.IsValid() {
+			parens[d.Doc] = [2]int{fset.Position(d.Lparen).Line, fset.Position(d.Rparen).Line}
+		}
+	}
+
+	for _, cg := range f.Comments {
+		for _, c := range cg.List {
+			if !strings.HasPrefix(c.Text, directivePrefix) {
+				continue
+			}
+
+			name, reason, ok := parseDirective(c.Text)
+			if !ok {
+				ig.report(file, c, "malformed directive, want //commentcov:ignore [reason=\"...\"]")
+				continue
+			}
+
+			if reason == "" {
+				reason = UnspecifiedReason
+			}
+
+			switch name {
+			case ignoreDirective:
+				g := &ignoreGroup{cg: cg, reason: reason}
+				if p, ok := parens[cg]; ok {
+					g.start, g.end = p[0], p[1]
+				}
+				ig.groups = append(ig.groups, g)
+
+			case ignoreFileDirective:
+				if cg.End() > f.Package {
+					ig.report(file, c, "//commentcov:ignore-file must be placed above the package clause")
+					continue
+				}
+
+				ig.file = &ignoreGroup{cg: cg, reason: reason}
+
+			default:
+				ig.report(file, c, "unknown directive //commentcov:"+name)
+			}
+		}
+	}
+
+	return ig
+}
+
+// parseDirective returns the name and the reason of the given directive comment.
+func parseDirective(text string) (string, string, bool) {
+	m := directiveSyntaxRe.FindStringSubmatch(text)
+	if m == nil {
+		return "", "", false
+	}
+
+	return m[1], strings.TrimSpace(m[2]), true
+}
+
+// report appends the Diagnostic of the malformed directive.
+func (ig *Ignores) report(file string, c *ast.Comment, message string) {
+	ig.Diagnostics = append(ig.Diagnostics, &Diagnostic{
+		Check:   IgnoreCheck,
+		File:    file,
+		Block:   NewBlock(ig.fset, c.Pos(), c.End()),
+		Message: message,
+	})
+}
+
+// Of returns the reason if the given CoverageItem is excluded by the ignore directives.
+// The CoverageItem is excluded if the file is ignored, the directive is in its header comments,
+// or the directive is in the doc comment of the grouped declaration which it belongs to.
+func (ig *Ignores) Of(ci *proto.CoverageItem) (string, bool) {
+	if ig.file != nil {
+		return ig.file.reason, true
+	}
+
+	for _, g := range ig.groups {
+		if IsHeader(ig.fset, g.cg, ci.TargetBlock) {
+			return g.reason, true
+		}
+
+		if g.start > 0 && g.start < int(ci.TargetBlock.StartLine) && int(ci.TargetBlock.EndLine) < g.end {
+			return g.reason, true
+		}
+	}
+
+	return "", false
+}
+
+// Filter returns the CoverageItems not excluded, and the Ignored ones.
+func (ig *Ignores) Filter(items []*proto.CoverageItem) ([]*proto.CoverageItem, []*Ignored) {
+	kept := make([]*proto.CoverageItem, 0, len(items))
+	ignored := []*Ignored{}
+	for _, ci := range items {
+		if reason, ok := ig.Of(ci); ok {
+			ignored = append(ignored, &Ignored{Item: ci, Reason: reason})
+			continue
+		}
+
+		kept = append(kept, ci)
+	}
+
+	return kept, ignored
+}
@@ -0,0 +1,159 @@
+package ast_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/commentcov/commentcov/proto"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	myAst "github.com/commentcov/commentcov-plugin-go/ast"
+)
+
+// TestIgnores is the unittest for NewIgnores and Ignores.Filter.
+//
+//nolint:funlen
+func TestIgnores(t *testing.T) {
+	tests := []struct {
+		name            string
+		src             string
+		wantKept        []string
+		wantIgnored     map[string]string
+		wantDiagnostics []*myAst.Diagnostic
+	}{
+		{
+			name: "declarations",
+			src: `package hoge
+
+// Foo does.
+//
+//commentcov:ignore reason="generated shim"
+func Foo() {}
+
+//commentcov:ignore reason="deprecated"
+type Bar struct{}
+
+//commentcov:ignore reason="enum values"
+const (
+	A = 1
+	B = 2
+)
+
+// Baz does.
+func Baz() {}
+`,
+			wantKept: []string{"hoge", "Baz"},
+			wantIgnored: map[string]string{
+				"Foo": "generated shim",
+				"Bar": "deprecated",
+				"A":   "enum values",
+				"B":   "enum values",
+			},
+			wantDiagnostics: []*myAst.Diagnostic{},
+		},
+		{
+			name: "file",
+			src: `//commentcov:ignore-file reason="vendored copy"
+
+package hoge
+
+func Foo() {}
+`,
+			wantKept: []string{},
+			wantIgnored: map[string]string{
+				"hoge": "vendored copy",
+				"Foo":  "vendored copy",
+			},
+			wantDiagnostics: []*myAst.Diagnostic{},
+		},
+		{
+			name: "without reasons",
+			src: `//commentcov:ignore-file
+
+package hoge
+
+//commentcov:ignore
+func Foo() {}
+`,
+			wantKept: []string{},
+			wantIgnored: map[string]string{
+				"hoge": myAst.UnspecifiedReason,
+				"Foo":  myAst.UnspecifiedReason,
+			},
+			wantDiagnostics: []*myAst.Diagnostic{},
+		},
+		{
+			name: "malformed",
+			src: `package hoge
+
+//commentcov:ignore reason=generated
+func Foo() {}
+
+//commentcov:ignroe reason="typo"
+func Bar() {}
+`,
+			wantKept:    []string{"hoge", "Foo", "Bar"},
+			wantIgnored: map[string]string{},
+			wantDiagnostics: []*myAst.Diagnostic{
+				{
+					Check:   myAst.IgnoreCheck,
+					File:    "hoge.go",
+					Block:   &proto.Block{StartLine: 3, StartColumn: 1, EndLine: 3, EndColumn: 37},
+					Message: "malformed directive, want //commentcov:ignore [reason=\"...\"]",
+				},
+				{
+					Check:   myAst.IgnoreCheck,
+					File:    "hoge.go",
+					Block:   &proto.Block{StartLine: 6, StartColumn: 1, EndLine: 6, EndColumn: 34},
+					Message: "unknown directive //commentcov:ignroe",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			f, err := parser.ParseFile(fset, "hoge.go", tt.src, parser.ParseComments)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ig := myAst.NewIgnores("hoge.go", fset, f)
+
+			items := []*proto.CoverageItem{myAst.ProcessPackageCoverage("hoge.go", fset, f)}
+			for _, decl := range f.Decls {
+				switch d := decl.(type) {
+				case *ast.FuncDecl:
+					items = append(items, myAst.ProcessFunctionCoverage("hoge.go", fset, f, d))
+				case *ast.GenDecl:
+					items = append(items, myAst.ProcessGenDeclCoverage("hoge.go", fset, f, d)...)
+				}
+			}
+			kept, ignored := ig.Filter(items)
+
+			gotKept := []string{}
+			for _, ci := range kept {
+				gotKept = append(gotKept, ci.Identifier)
+			}
+			if diff := cmp.Diff(tt.wantKept, gotKept); diff != "" {
+				t.Errorf("kept CoverageItems are mismatch (-want +got):%s\n", diff)
+			}
+
+			gotIgnored := map[string]string{}
+			for _, i := range ignored {
+				gotIgnored[i.Item.Identifier] = i.Reason
+			}
+			if diff := cmp.Diff(tt.wantIgnored, gotIgnored); diff != "" {
+				t.Errorf("Ignored values are mismatch (-want +got):%s\n", diff)
+			}
+
+			if diff := cmp.Diff(tt.wantDiagnostics, ig.Diagnostics, cmpopts.IgnoreUnexported(proto.Block{})); diff != "" {
+				t.Errorf("Diagnostic values are mismatch (-want +got):%s\n", diff)
+			}
+		})
+	}
+}
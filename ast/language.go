@@ -0,0 +1,181 @@
+package ast
+
+import (
+	"fmt"
+	"go/ast"
+	"go/doc/comment"
+	"go/token"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/commentcov/commentcov/proto"
+)
+
+// LanguageCheck is the name of the check for the language of the header comments.
+const LanguageCheck = "language"
+
+// The labels of the languages detected by DetectLanguage.
+// The scripts shared by several languages are labeled with the script names.
+const (
+	LanguageUnknown    = "unknown"
+	LanguageEnglish    = "en"
+	LanguageJapanese   = "ja"
+	LanguageChinese    = "zh"
+	LanguageKorean     = "ko"
+	LanguageLatin      = "latin"
+	LanguageCyrillic   = "cyrillic"
+	LanguageGreek      = "greek"
+	LanguageArabic     = "arabic"
+	LanguageHebrew     = "hebrew"
+	LanguageThai       = "thai"
+	LanguageDevanagari = "devanagari"
+)
+
+// ideographWeight is the weight of a CJK and Hangul character against a letter of the alphabetic scripts.
+// An ideograph or a syllable carries about as much as a few letters.
+const ideographWeight = 3
+
+// minEnglishRatio is the minimum ratio of the English words for the Latin script text to be labeled as English.
+const minEnglishRatio = 0.5
+
+var (
+	// englishWords is the built-in English word list used to tell English from the other Latin script languages.
+	englishWords     Dictionary
+	englishWordsOnce sync.Once
+)
+
+// scripts are the scripts detected with the labels of them.
+// Han is not listed, since it is shared by Japanese and Chinese.
+var scripts = []struct {
+	label string
+	table *unicode.RangeTable
+}{
+	{LanguageLatin, unicode.Latin},
+	{LanguageCyrillic, unicode.Cyrillic},
+	{LanguageGreek, unicode.Greek},
+	{LanguageArabic, unicode.Arabic},
+	{LanguageHebrew, unicode.Hebrew},
+	{LanguageThai, unicode.Thai},
+	{LanguageDevanagari, unicode.Devanagari},
+}
+
+// DetectLanguage returns the label of the dominant language of the given comment text.
+// It is a heuristic based on the scripts of the letters only, so it works offline:
+// the kana tell Japanese from Chinese, and the built-in English word list tells English from the other Latin script languages.
+// The code spans and the URLs are not taken into account.
+// It returns LanguageUnknown if the text has no letters.
+func DetectLanguage(text string) string {
+	var p comment.Parser
+	doc := p.Parse(Normalize(text))
+
+	plain := strings.Join(docPlainTexts(doc), "\n")
+	plain = codeSpanRe.ReplaceAllString(plain, " ")
+	plain = urlRe.ReplaceAllString(plain, " ")
+
+	counts := map[string]int{}
+	kana := false
+	for _, r := range plain {
+		switch {
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+			kana = true
+			counts[LanguageJapanese] += ideographWeight
+		case unicode.Is(unicode.Han, r):
+			counts[LanguageChinese] += ideographWeight
+		case unicode.Is(unicode.Hangul, r):
+			counts[LanguageKorean] += ideographWeight
+		default:
+			for _, s := range scripts {
+				if unicode.Is(s.table, r) {
+					counts[s.label]++
+					break
+				}
+			}
+		}
+	}
+
+	// Han is used along with the kana in Japanese.
+	if kana {
+		counts[LanguageJapanese] += counts[LanguageChinese]
+		delete(counts, LanguageChinese)
+	}
+
+	label := LanguageUnknown
+	best := 0
+	for _, l := range []string{
+		LanguageLatin, LanguageJapanese, LanguageChinese, LanguageKorean, LanguageCyrillic,
+		LanguageGreek, LanguageArabic, LanguageHebrew, LanguageThai, LanguageDevanagari,
+	} {
+		if counts[l] > best {
+			label = l
+			best = counts[l]
+		}
+	}
+
+	if label == LanguageLatin && isEnglish(plain) {
+		return LanguageEnglish
+	}
+
+	return label
+}
+
+// isEnglish returns true if most of the Latin script words of the given text are in the built-in English word list.
+func isEnglish(text string) bool {
+	englishWordsOnce.Do(func() {
+		englishWords = BuiltinDictionary()
+	})
+
+	total := 0
+	found := 0
+	for _, word := range spellWordRe.FindAllString(text, -1) {
+		if !isSpellCheckable(word) || !isLatinWord(word) {
+			continue
+		}
+
+		total++
+		if englishWords.Contains(word) {
+			found++
+		}
+	}
+
+	return total == 0 || float64(found)/float64(total) >= minEnglishRatio
+}
+
+// isLatinWord returns true if the given word consists of the Latin script letters only.
+func isLatinWord(word string) bool {
+	for _, r := range word {
+		if !unicode.Is(unicode.Latin, r) && r != '\'' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// CheckLanguage reports the CoverageItem whose HeaderComments are not written in the given language.
+// The Diagnostic is positioned at the first header comment group.
+func CheckLanguage(file string, fset *token.FileSet, f *ast.File, ci *proto.CoverageItem, language string) []*Diagnostic {
+	if len(ci.HeaderComments) == 0 {
+		return []*Diagnostic{}
+	}
+
+	detected := DetectLanguage(commentsText(ci.HeaderComments))
+	if detected == language || detected == LanguageUnknown {
+		return []*Diagnostic{}
+	}
+
+	cgs := HeaderCommentGroups(fset, f, ci.TargetBlock)
+	if len(cgs) == 0 {
+		return []*Diagnostic{}
+	}
+
+	return []*Diagnostic{
+		{
+			Check:      LanguageCheck,
+			File:       file,
+			Identifier: ci.Identifier,
+			Block:      NewBlock(fset, cgs[0].Pos(), cgs[0].End()),
+			Message:    fmt.Sprintf("comment is written in %s, not in %s", detected, language),
+		},
+	}
+}
@@ -0,0 +1,128 @@
+package ast_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/commentcov/commentcov/proto"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	myAst "github.com/commentcov/commentcov-plugin-go/ast"
+)
+
+// TestDetectLanguage is the unittest for DetectLanguage.
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "english",
+			text: "Foo returns the value stored in the cache.\n",
+			want: myAst.LanguageEnglish,
+		},
+		{
+			name: "japanese with identifiers",
+			text: "Foo はキャッシュに保存された `Value` を返す。\n",
+			want: myAst.LanguageJapanese,
+		},
+		{
+			name: "chinese",
+			text: "Foo 返回缓存中的值。\n",
+			want: myAst.LanguageChinese,
+		},
+		{
+			name: "korean",
+			text: "Foo 는 캐시에 저장된 값을 반환합니다.\n",
+			want: myAst.LanguageKorean,
+		},
+		{
+			name: "cyrillic",
+			text: "Foo возвращает значение из кэша.\n",
+			want: myAst.LanguageCyrillic,
+		},
+		{
+			name: "other latin script language",
+			text: "Foo devuelve el valor almacenado en la memoria caché.\n",
+			want: myAst.LanguageLatin,
+		},
+		{
+			name: "code only",
+			text: "`x := 1`\n",
+			want: myAst.LanguageUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := myAst.DetectLanguage(tt.text)
+			if tt.want != got {
+				t.Errorf("want %s, got %s\n", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestCheckLanguage is the unittest for CheckLanguage.
+func TestCheckLanguage(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []*myAst.Diagnostic
+	}{
+		{
+			name: "written in the language",
+			src: `package hoge
+
+// Foo returns the value.
+func Foo() int { return 0 }
+
+func Bar() int { return 0 }
+`,
+			want: []*myAst.Diagnostic{},
+		},
+		{
+			name: "written in another language",
+			src: `package hoge
+
+// Foo は値を返す。
+func Foo() int { return 0 }
+`,
+			want: []*myAst.Diagnostic{
+				{
+					Check:      myAst.LanguageCheck,
+					File:       "hoge.go",
+					Identifier: "Foo",
+					Block:      &proto.Block{StartLine: 3, StartColumn: 1, EndLine: 3, EndColumn: 26},
+					Message:    "comment is written in ja, not in en",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			f, err := parser.ParseFile(fset, "hoge.go", tt.src, parser.ParseComments)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := []*myAst.Diagnostic{}
+			for _, decl := range f.Decls {
+				if d, ok := decl.(*ast.FuncDecl); ok {
+					ci := myAst.ProcessFunctionCoverage("hoge.go", fset, f, d)
+					got = append(got, myAst.CheckLanguage("hoge.go", fset, f, ci, myAst.LanguageEnglish)...)
+				}
+			}
+
+			if diff := cmp.Diff(tt.want, got, cmpopts.IgnoreUnexported(proto.Block{})); diff != "" {
+				t.Errorf("Diagnostic values are mismatch (-want +got):%s\n", diff)
+			}
+		})
+	}
+}
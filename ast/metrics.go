@@ -0,0 +1,121 @@
+package ast
+
+import (
+	"fmt"
+	"go/doc/comment"
+	"strings"
+
+	"github.com/commentcov/commentcov/proto"
+)
+
+// CommentLengthCheck is the name of the check for the length of the header comments.
+const CommentLengthCheck = "comment_length"
+
+// Metrics is the structural metrics of the doc comments.
+type Metrics struct {
+	// Lines is the number of the non-blank lines.
+	Lines int
+	// Paragraphs is the number of the paragraphs.
+	Paragraphs int
+	// Words is the number of the words, excluding the code blocks.
+	Words int
+	// CodeBlocks is the number of the code blocks.
+	CodeBlocks int
+	// Lists is the number of the lists.
+	Lists int
+	// DocLinks is the number of the doc links.
+	DocLinks int
+	// Headings is the number of the headings.
+	Headings int
+}
+
+// IsOneLiner returns true if the doc comments consist of a single line.
+func (m *Metrics) IsOneLiner() bool {
+	return m.Lines == 1
+}
+
+// Add adds the other Metrics to the Metrics for the aggregation.
+func (m *Metrics) Add(o *Metrics) {
+	m.Lines += o.Lines
+	m.Paragraphs += o.Paragraphs
+	m.Words += o.Words
+	m.CodeBlocks += o.CodeBlocks
+	m.Lists += o.Lists
+	m.DocLinks += o.DocLinks
+	m.Headings += o.Headings
+}
+
+// ProcessMetrics measures the structural metrics of the given HeaderComments parsed as doc comments.
+func ProcessMetrics(hcs []*proto.Comment) *Metrics {
+	p := comment.Parser{
+		LookupSym: func(_, _ string) bool {
+			return true
+		},
+	}
+
+	m := &Metrics{}
+	for _, hc := range hcs {
+		for _, l := range strings.Split(hc.Comment, "\n") {
+			if strings.TrimSpace(l) != "" {
+				m.Lines++
+			}
+		}
+
+		doc := p.Parse(hc.Comment)
+		for _, b := range doc.Content {
+			m.addBlock(b)
+		}
+	}
+
+	return m
+}
+
+// addBlock adds the metrics of the given comment.Block.
+func (m *Metrics) addBlock(b comment.Block) {
+	switch blk := b.(type) {
+	case *comment.Paragraph:
+		m.Paragraphs++
+		m.addText(blk.Text)
+
+	case *comment.Heading:
+		m.Headings++
+		m.addText(blk.Text)
+
+	case *comment.Code:
+		m.CodeBlocks++
+
+	case *comment.List:
+		m.Lists++
+		for _, item := range blk.Items {
+			for _, c := range item.Content {
+				if p, ok := c.(*comment.Paragraph); ok {
+					m.addText(p.Text)
+				}
+			}
+		}
+	}
+}
+
+// addText adds the metrics of the given comment.Text.
+func (m *Metrics) addText(ts []comment.Text) {
+	m.Words += len(strings.Fields(plainText(ts)))
+	m.DocLinks += len(textDocLinks(ts))
+}
+
+// CheckCommentLength reports the CoverageItem whose HeaderComments have fewer words than minWords.
+// The CoverageItems without HeaderComments are not checked, and the check is disabled if minWords is 0.
+func CheckCommentLength(file string, ci *proto.CoverageItem, m *Metrics, minWords int) []*Diagnostic {
+	if minWords <= 0 || len(ci.HeaderComments) == 0 || m.Words >= minWords {
+		return []*Diagnostic{}
+	}
+
+	return []*Diagnostic{
+		{
+			Check:      CommentLengthCheck,
+			File:       file,
+			Identifier: ci.Identifier,
+			Block:      ci.HeaderComments[0].Block,
+			Message:    fmt.Sprintf("comment has %d words, fewer than %d", m.Words, minWords),
+		},
+	}
+}
@@ -0,0 +1,82 @@
+package ast_test
+
+import (
+	"testing"
+
+	"github.com/commentcov/commentcov/proto"
+	"github.com/google/go-cmp/cmp"
+
+	myAst "github.com/commentcov/commentcov-plugin-go/ast"
+)
+
+// TestProcessMetrics is the unittest for ProcessMetrics.
+func TestProcessMetrics(t *testing.T) {
+	tests := []struct {
+		name string
+		hcs  []*proto.Comment
+		want *myAst.Metrics
+	}{
+		{
+			name: "no comment",
+			hcs:  []*proto.Comment{},
+			want: &myAst.Metrics{},
+		},
+		{
+			name: "one-liner",
+			hcs: []*proto.Comment{
+				{Comment: "Get returns the value.\n"},
+			},
+			want: &myAst.Metrics{
+				Lines:      1,
+				Paragraphs: 1,
+				Words:      4,
+			},
+		},
+		{
+			name: "structured comment",
+			hcs: []*proto.Comment{
+				{Comment: "MyFunc returns [MyType].\nSee [fmt.Println].\n\n# Usage\n\n\tMyFunc()\n\nOptions:\n  - a is first\n  - b\n"},
+			},
+			want: &myAst.Metrics{
+				Lines:      7,
+				Paragraphs: 2,
+				Words:      11,
+				CodeBlocks: 1,
+				Lists:      1,
+				DocLinks:   2,
+				Headings:   1,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := myAst.ProcessMetrics(tt.hcs)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Metrics values are mismatch (-want +got):%s\n", diff)
+			}
+		})
+	}
+}
+
+// TestMetricsIsOneLiner is the unittest for Metrics.IsOneLiner.
+func TestMetricsIsOneLiner(t *testing.T) {
+	tests := []struct {
+		name string
+		m    *myAst.Metrics
+		want bool
+	}{
+		{name: "no line", m: &myAst.Metrics{}, want: false},
+		{name: "single line", m: &myAst.Metrics{Lines: 1}, want: true},
+		{name: "multi lines", m: &myAst.Metrics{Lines: 2}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.m.IsOneLiner()
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("bool values are mismatch (-want +got):%s\n", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package ast
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+// goModFile is the name of the module definition file.
+const goModFile = "go.mod"
+
+// Module is the Go module which the packages belong to.
+type Module struct {
+	// Path is the module path declared in go.mod.
+	Path string
+	// Dir is the root directory of the module, which has go.mod.
+	Dir string
+}
+
+// ModuleRoot returns the directory of the nearest go.mod walking up from the given directory.
+// It returns "" if there is no go.mod.
+func ModuleRoot(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+
+	for d := abs; ; d = filepath.Dir(d) {
+		if fi, err := os.Stat(filepath.Join(d, goModFile)); err == nil && !fi.IsDir() {
+			return d
+		}
+
+		if parent := filepath.Dir(d); parent == d {
+			return ""
+		}
+	}
+}
+
+// LoadModule reads go.mod in the given root directory of the module.
+func LoadModule(root string) (*Module, error) {
+	file := filepath.Join(root, goModFile)
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	p := modfile.ModulePath(b)
+	if p == "" {
+		return nil, fmt.Errorf("%s: no module path", file)
+	}
+
+	return &Module{
+		Path: p,
+		Dir:  root,
+	}, nil
+}
+
+// ImportPath returns the import path of the package in the given directory of the Module.
+func (m *Module) ImportPath(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+
+	rel, err := filepath.Rel(m.Dir, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return ""
+	}
+
+	return path.Join(m.Path, filepath.ToSlash(rel))
+}
+
+// QualifiedIdentifier returns the identifier qualified with the import path of its package,
+// like `github.com/org/repo/pkg.Type.Method`. The package itself is identified by the import path.
+// It returns the bare identifier if the import path is unknown.
+func QualifiedIdentifier(importPath, recv, name string) string {
+	key := ReachabilityKey(recv, name)
+	switch {
+	case importPath == "":
+		return key
+	case key == "":
+		return importPath
+	}
+
+	return importPath + "." + key
+}
+
+// BaseIdentifier returns the last part of the identifier which may be qualified by QualifiedIdentifier,
+// or prefixed with the receiver type like `Type.Field`.
+func BaseIdentifier(identifier string) string {
+	base := path.Base(identifier)
+	if i := strings.LastIndex(base, "."); i >= 0 {
+		return base[i+1:]
+	}
+
+	return base
+}
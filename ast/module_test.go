@@ -0,0 +1,286 @@
+package ast_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	myAst "github.com/commentcov/commentcov-plugin-go/ast"
+)
+
+// TestModule_ImportPath is the unittest for Module.ImportPath.
+func TestModule_ImportPath(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("module github.com/org/repo\n\ngo 1.24\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := myAst.LoadModule(myAst.ModuleRoot(filepath.Join(root, "pkg", "sub")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		dir  string
+		want string
+	}{
+		{
+			name: "module root",
+			dir:  root,
+			want: "github.com/org/repo",
+		},
+		{
+			name: "sub package",
+			dir:  filepath.Join(root, "pkg", "sub"),
+			want: "github.com/org/repo/pkg/sub",
+		},
+		{
+			name: "outside of module",
+			dir:  filepath.Dir(root),
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := m.ImportPath(tt.dir)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ImportPath() values are mismatch (-want +got):%s\n", diff)
+			}
+		})
+	}
+}
+
+// TestQualifiedIdentifier is the unittest for QualifiedIdentifier and BaseIdentifier.
+func TestQualifiedIdentifier(t *testing.T) {
+	tests := []struct {
+		name       string
+		importPath string
+		recv       string
+		identifier string
+		want       string
+		wantBase   string
+	}{
+		{
+			name:       "package",
+			importPath: "github.com/org/repo/pkg",
+			want:       "github.com/org/repo/pkg",
+			wantBase:   "pkg",
+		},
+		{
+			name:       "function",
+			importPath: "github.com/org/repo/pkg",
+			identifier: "Foo",
+			want:       "github.com/org/repo/pkg.Foo",
+			wantBase:   "Foo",
+		},
+		{
+			name:       "method",
+			importPath: "github.com/org/repo/pkg",
+			recv:       "Type",
+			identifier: "Method",
+			want:       "github.com/org/repo/pkg.Type.Method",
+			wantBase:   "Method",
+		},
+		{
+			name:       "unknown import path",
+			identifier: "Foo",
+			want:       "Foo",
+			wantBase:   "Foo",
+		},
+		{
+			name:       "unqualified field",
+			recv:       "Type",
+			identifier: "Field",
+			want:       "Type.Field",
+			wantBase:   "Field",
+		},
+		{
+			name:       "single-segment module",
+			importPath: "sample",
+			identifier: "Foo",
+			want:       "sample.Foo",
+			wantBase:   "Foo",
+		},
+		{
+			name:       "package of single-segment module",
+			importPath: "sample",
+			want:       "sample",
+			wantBase:   "sample",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := myAst.QualifiedIdentifier(tt.importPath, tt.recv, tt.identifier)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("QualifiedIdentifier() values are mismatch (-want +got):%s\n", diff)
+			}
+
+			if diff := cmp.Diff(tt.wantBase, myAst.BaseIdentifier(got)); diff != "" {
+				t.Errorf("BaseIdentifier() values are mismatch (-want +got):%s\n", diff)
+			}
+		})
+	}
+}
+
+// TestAnalyzeFile_QualifiedIdentifiers is the unittest for Analyzer.AnalyzeFile with the qualified identifiers.
+func TestAnalyzeFile_QualifiedIdentifiers(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "hoge")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("module github.com/org/repo\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	src := `// Package hoge is a package.
+package hoge
+
+// Foo is a type.
+type Foo struct{}
+
+// Bar is a method.
+func (f *Foo) Bar() {}
+
+// Baz is a function.
+func Baz() {}
+`
+	file := filepath.Join(dir, "hoge.go")
+	if err := os.WriteFile(file, []byte(src), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		qualified bool
+		want      []string
+	}{
+		{
+			name:      "qualified",
+			qualified: true,
+			want: []string{
+				"github.com/org/repo/hoge",
+				"github.com/org/repo/hoge.Foo",
+				"github.com/org/repo/hoge.Foo.Bar",
+				"github.com/org/repo/hoge.Baz",
+			},
+		},
+		{
+			name:      "bare",
+			qualified: false,
+			want:      []string{"hoge", "Foo", "Bar", "Baz"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := myAst.DefaultOptions()
+			options.QualifiedIdentifiers = tt.qualified
+
+			res, err := myAst.NewAnalyzer(options).AnalyzeFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := []string{}
+			for _, ci := range res.Items {
+				got = append(got, ci.Identifier)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Identifier values are mismatch (-want +got):%s\n", diff)
+			}
+
+			if diff := cmp.Diff("github.com/org/repo/hoge", res.Package.ImportPath); diff != "" {
+				t.Errorf("ImportPath values are mismatch (-want +got):%s\n", diff)
+			}
+		})
+	}
+}
+
+// TestAnalyzeFile_QualifiedDiagnostics is the unittest for the identifiers of the Diagnostics qualified by Analyzer.AnalyzeFile.
+func TestAnalyzeFile_QualifiedDiagnostics(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("module github.com/org/repo\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// the methods share the bare identifier String.
+	src := `// Package hoge is a package.
+package hoge
+
+// A is a type.
+type A struct{}
+
+// B is a type.
+type B struct{}
+
+// ToString returns the string of A.
+func (a A) String() string { return "a" }
+
+// ToString returns the string of B.
+func (b B) String() string { return "b" }
+`
+	file := filepath.Join(root, "hoge.go")
+	if err := os.WriteFile(file, []byte(src), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	options := myAst.DefaultOptions()
+	options.QualifiedIdentifiers = true
+
+	res, err := myAst.NewAnalyzer(options).AnalyzeFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := []string{}
+	for _, d := range res.Diagnostics {
+		if d.Check == myAst.StaleCommentCheck {
+			got = append(got, d.Identifier)
+		}
+	}
+
+	want := []string{"github.com/org/repo.A.String", "github.com/org/repo.B.String"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Identifier values are mismatch (-want +got):%s\n", diff)
+	}
+}
+
+// TestAnalyzeFile_DetailModule is the unittest for the modules of the Details set by Analyzer.AnalyzeFile.
+func TestAnalyzeFile_DetailModule(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("module github.com/org/repo\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	src := `// Package hoge is a package.
+package hoge
+
+func Undocumented() {}
+`
+	file := filepath.Join(root, "hoge.go")
+	if err := os.WriteFile(file, []byte(src), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := myAst.NewAnalyzer(myAst.DefaultOptions()).AnalyzeFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := map[string]string{}
+	for _, ci := range res.Items {
+		got[ci.Identifier] = res.Details[ci].Module
+	}
+
+	want := map[string]string{"hoge": "github.com/org/repo", "Undocumented": "github.com/org/repo"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Module values are mismatch (-want +got):%s\n", diff)
+	}
+}
@@ -0,0 +1,108 @@
+package ast
+
+import (
+	"go/build"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// The origins of the files.
+const (
+	// OriginFirstParty is the origin of the files of the project itself.
+	OriginFirstParty = "first_party"
+	// OriginVendor is the origin of the files in the vendor directories, which have vendor/modules.txt.
+	OriginVendor = "vendor"
+	// OriginModuleCache is the origin of the files in the Go module cache.
+	OriginModuleCache = "module_cache"
+	// OriginThirdParty is the origin of the files under the configured third-party roots, like third_party/.
+	OriginThirdParty = "third_party"
+)
+
+// The modes of handling the files not of OriginFirstParty.
+const (
+	// ThirdPartySkip skips the files.
+	ThirdPartySkip = "skip"
+	// ThirdPartyLabel analyzes the files labeled with their origins, keeping their items out of the coverage.
+	ThirdPartyLabel = "label"
+)
+
+// vendorDir is the name of the vendor directories.
+const vendorDir = "vendor"
+
+// vendorModulesFile is the file listing the vendored modules, which the go command writes by `go mod vendor`.
+const vendorModulesFile = "modules.txt"
+
+// goModCacheEnv is the name of the environment variable of the module cache directory.
+const goModCacheEnv = "GOMODCACHE"
+
+// OriginOf returns the origin of the given file.
+// roots are the directories of the third-party code.
+func OriginOf(file string, roots []string) string {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return OriginFirstParty
+	}
+
+	switch {
+	case IsVendored(abs):
+		return OriginVendor
+	case isUnder(abs, ModuleCache()):
+		return OriginModuleCache
+	}
+
+	for _, root := range roots {
+		if r, err := filepath.Abs(root); err == nil && isUnder(abs, r) {
+			return OriginThirdParty
+		}
+	}
+
+	return OriginFirstParty
+}
+
+// IsVendored returns true if the given file is in a vendor directory which has vendor/modules.txt.
+// The directories named vendor without it, like the ones of the web assets, are not regarded as vendored.
+func IsVendored(file string) bool {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return false
+	}
+
+	for d := filepath.Dir(abs); ; d = filepath.Dir(d) {
+		if filepath.Base(d) == vendorDir {
+			if fi, err := os.Stat(filepath.Join(d, vendorModulesFile)); err == nil && !fi.IsDir() {
+				return true
+			}
+		}
+
+		if parent := filepath.Dir(d); parent == d {
+			return false
+		}
+	}
+}
+
+// ModuleCache returns the directory of the Go module cache.
+// It is GOMODCACHE if set, otherwise pkg/mod under the first GOPATH, as the go command does.
+func ModuleCache() string {
+	if dir := os.Getenv(goModCacheEnv); dir != "" {
+		return dir
+	}
+
+	gopath := filepath.SplitList(build.Default.GOPATH)
+	if len(gopath) == 0 || gopath[0] == "" {
+		return ""
+	}
+
+	return filepath.Join(gopath[0], "pkg", "mod")
+}
+
+// isUnder returns true if the file is under the directory.
+func isUnder(file, dir string) bool {
+	if dir == "" {
+		return false
+	}
+
+	rel, err := filepath.Rel(dir, file)
+
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
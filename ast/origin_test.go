@@ -0,0 +1,142 @@
+package ast_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	myAst "github.com/commentcov/commentcov-plugin-go/ast"
+)
+
+// TestOriginOf is the unittest for OriginOf.
+func TestOriginOf(t *testing.T) {
+	root := t.TempDir()
+	cache := filepath.Join(t.TempDir(), "mod")
+	t.Setenv("GOMODCACHE", cache)
+
+	if err := os.MkdirAll(filepath.Join(root, "vendor"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "vendor", "modules.txt"), []byte("# example.com/dep v1.0.0\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		file string
+		want string
+	}{
+		{
+			name: "first party",
+			file: filepath.Join(root, "pkg", "hoge.go"),
+			want: myAst.OriginFirstParty,
+		},
+		{
+			name: "vendored",
+			file: filepath.Join(root, "vendor", "example.com", "dep", "dep.go"),
+			want: myAst.OriginVendor,
+		},
+		{
+			name: "vendor without modules.txt",
+			file: filepath.Join(root, "web", "vendor", "hoge.go"),
+			want: myAst.OriginFirstParty,
+		},
+		{
+			name: "module cache",
+			file: filepath.Join(cache, "example.com", "dep@v1.0.0", "dep.go"),
+			want: myAst.OriginModuleCache,
+		},
+		{
+			name: "third-party root",
+			file: filepath.Join(root, "third_party", "dep", "dep.go"),
+			want: myAst.OriginThirdParty,
+		},
+		{
+			name: "similar to third-party root",
+			file: filepath.Join(root, "third_party_tools", "hoge.go"),
+			want: myAst.OriginFirstParty,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := myAst.OriginOf(tt.file, []string{filepath.Join(root, "third_party")})
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("OriginOf() values are mismatch (-want +got):%s\n", diff)
+			}
+		})
+	}
+}
+
+// TestAnalyzeFile_ThirdParty is the unittest for Analyzer.AnalyzeFile of the third-party code.
+func TestAnalyzeFile_ThirdParty(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "third_party", "dep")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	src := `// Package dep is a copy.
+package dep
+
+func Foo() {}
+`
+	file := filepath.Join(dir, "dep.go")
+	if err := os.WriteFile(file, []byte(src), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name           string
+		mode           string
+		wantNil        bool
+		wantItems      int
+		wantThirdParty int
+	}{
+		{
+			name:    "skip",
+			mode:    myAst.ThirdPartySkip,
+			wantNil: true,
+		},
+		{
+			name:           "label",
+			mode:           myAst.ThirdPartyLabel,
+			wantItems:      0,
+			wantThirdParty: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := myAst.DefaultOptions()
+			options.ThirdPartyRoots = []string{filepath.Join(root, "third_party")}
+			options.ThirdPartyMode = tt.mode
+
+			res, err := myAst.NewAnalyzer(options).AnalyzeFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.wantNil {
+				if res != nil {
+					t.Errorf("want nil Result, got %v", res)
+				}
+				return
+			}
+
+			if diff := cmp.Diff(myAst.OriginThirdParty, res.Origin); diff != "" {
+				t.Errorf("Origin values are mismatch (-want +got):%s\n", diff)
+			}
+
+			if diff := cmp.Diff(tt.wantItems, len(res.Items)); diff != "" {
+				t.Errorf("Items values are mismatch (-want +got):%s\n", diff)
+			}
+
+			if diff := cmp.Diff(tt.wantThirdParty, len(res.ThirdParty)); diff != "" {
+				t.Errorf("ThirdParty values are mismatch (-want +got):%s\n", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,291 @@
+package ast
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// mainPackage is the name of the packages building commands.
+const mainPackage = "main"
+
+// Package is the Go package which a file belongs to.
+// It holds the declarations across the files of the package.
+type Package struct {
+	// Name is the package name.
+	Name string
+	// Dir is the directory of the package. It is empty unless loaded by LoadPackage.
+	Dir string
+	// Internal is true if the package is an internal package, which cannot be imported from outside of its parent tree.
+	Internal bool
+	// Module is the module which the package belongs to. It is nil if there is no go.mod.
+	Module *Module
+	// ImportPath is the import path of the package resolved from the nearest go.mod. It is empty if unknown.
+	// The external test package has the import path of the package under test suffixed with `_test`.
+	ImportPath string
+	// Files are the files of the package.
+	Files []*ast.File
+	// Decls are the package level identifiers.
+	Decls map[string]bool
+	// Types are the declared type names.
+	Types map[string]bool
+	// Members are the methods and the fields of each type.
+	Members map[string]map[string]bool
+	// Examples are the example functions keyed by their targets. It is nil unless loaded by LoadExamples.
+	Examples map[string][]string
+
+	// reachable caches Reachable of the Package.
+	reachable map[string]bool
+}
+
+// NewPackage returns the Package composed of the given files.
+func NewPackage(name string, files ...*ast.File) *Package {
+	pkg := &Package{
+		Name:    name,
+		Files:   []*ast.File{},
+		Decls:   map[string]bool{},
+		Types:   map[string]bool{},
+		Members: map[string]map[string]bool{},
+	}
+
+	for _, f := range files {
+		pkg.AddFile(f)
+	}
+
+	return pkg
+}
+
+// IsExternalTest returns true if the Package is an external test package, like `hoge_test`.
+func (p *Package) IsExternalTest() bool {
+	return IsExternalTestPackage(p.Name)
+}
+
+// IsCommand returns true if the Package is a main package, which builds a command.
+func (p *Package) IsCommand() bool {
+	return p.Name == mainPackage
+}
+
+// Qualify returns the identifier declared in the Package qualified with ImportPath by QualifiedIdentifier.
+func (p *Package) Qualify(recv, name string) string {
+	return QualifiedIdentifier(p.ImportPath, recv, name)
+}
+
+// reachableKey returns true if the ReachabilityKey is in Reachable of the Package, caching it.
+func (p *Package) reachableKey(key string) bool {
+	if p.reachable == nil {
+		p.reachable = Reachable(p)
+	}
+
+	return p.reachable[key]
+}
+
+// AddFile adds the declarations of the given file to the Package.
+func (p *Package) AddFile(f *ast.File) {
+	p.Files = append(p.Files, f)
+	p.reachable = nil
+
+	for _, decl := range f.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Recv == nil {
+				p.Decls[d.Name.Name] = true
+				continue
+			}
+
+			if recv := ReceiverTypeName(d); recv != "" {
+				p.addMember(recv, d.Name.Name)
+			}
+
+		case *ast.GenDecl:
+			for _, s := range d.Specs {
+				switch spec := s.(type) {
+				case *ast.ValueSpec:
+					for _, name := range spec.Names {
+						p.Decls[name.Name] = true
+					}
+
+				case *ast.TypeSpec:
+					p.Decls[spec.Name.Name] = true
+					p.Types[spec.Name.Name] = true
+					p.addTypeMembers(spec)
+				}
+			}
+		}
+	}
+}
+
+// addMember registers the member name to the type.
+func (p *Package) addMember(typ, name string) {
+	if _, ok := p.Members[typ]; !ok {
+		p.Members[typ] = map[string]bool{}
+	}
+
+	p.Members[typ][name] = true
+}
+
+// addTypeMembers registers the fields of the struct and the methods of the interface.
+func (p *Package) addTypeMembers(spec *ast.TypeSpec) {
+	var fields *ast.FieldList
+	switch t := spec.Type.(type) {
+	case *ast.StructType:
+		fields = t.Fields
+	case *ast.InterfaceType:
+		fields = t.Methods
+	default:
+		return
+	}
+
+	for _, field := range fields.List {
+		for _, name := range field.Names {
+			p.addMember(spec.Name.Name, name.Name)
+		}
+
+		if len(field.Names) == 0 {
+			if name := embeddedTypeName(field.Type); name != "" {
+				p.addMember(spec.Name.Name, name)
+			}
+		}
+	}
+}
+
+// HasSymbol returns true if the package declares the symbol.
+// recv is the type name for the methods and the fields, or empty for the package level identifiers.
+func (p *Package) HasSymbol(recv, name string) bool {
+	if recv == "" {
+		return p.Decls[name]
+	}
+
+	return p.Types[recv] && p.Members[recv][name]
+}
+
+// LoadPackage parses the non-test Go files in dir declaring the package name,
+// or the test files for the external test package.
+func LoadPackage(fset *token.FileSet, dir, name string) (*Package, error) {
+	// the external test package consists only of the test files.
+	external := IsExternalTestPackage(name)
+	files, err := parseGoFiles(fset, dir, func(file string) bool {
+		return IsTestFile(file) == external
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pkg := NewPackage(name)
+	pkg.Dir = dir
+	pkg.Internal = IsInternal(dir)
+	for _, f := range files {
+		if f.Name.Name == name {
+			pkg.AddFile(f)
+		}
+	}
+
+	return pkg, nil
+}
+
+// parseGoFiles parses the Go files in dir whose names match.
+// The files failed to parse are skipped since they are not the target of the analysis.
+func parseGoFiles(fset *token.FileSet, dir string, match func(file string) bool) ([]*ast.File, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []*ast.File{}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".go") || !match(e.Name()) {
+			continue
+		}
+
+		f, err := parser.ParseFile(fset, filepath.Join(dir, e.Name()), nil, parser.SkipObjectResolution)
+		if err != nil {
+			continue
+		}
+
+		files = append(files, f)
+	}
+
+	return files, nil
+}
+
+// ReceiverTypeName returns the type name of the method receiver.
+func ReceiverTypeName(fdecl *ast.FuncDecl) string {
+	if fdecl.Recv == nil || len(fdecl.Recv.List) == 0 {
+		return ""
+	}
+
+	return embeddedTypeName(fdecl.Recv.List[0].Type)
+}
+
+// embeddedTypeName returns the local type name of the given type expression,
+// dereferencing the pointers and dropping the type parameters.
+func embeddedTypeName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.StarExpr:
+		return embeddedTypeName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	case *ast.IndexExpr:
+		return embeddedTypeName(t.X)
+	case *ast.IndexListExpr:
+		return embeddedTypeName(t.X)
+	case *ast.ParenExpr:
+		return embeddedTypeName(t.X)
+	}
+
+	return ""
+}
+
+// examplesOf returns the example functions documenting the target.
+// It returns nil if the examples are not loaded.
+func (p *Package) examplesOf(target string) []string {
+	if p.Examples == nil {
+		return nil
+	}
+
+	examples := p.Examples[target]
+	if examples == nil {
+		return []string{}
+	}
+
+	return examples
+}
+
+// SpecNames returns the identifiers declared by the same spec as each identifier of the given *ast.GenDecl.
+func SpecNames(gdecl *ast.GenDecl) map[string][]string {
+	names := map[string][]string{}
+	for _, s := range gdecl.Specs {
+		switch spec := s.(type) {
+		case *ast.ValueSpec:
+			idents := make([]string, 0, len(spec.Names))
+			for _, name := range spec.Names {
+				idents = append(idents, name.Name)
+			}
+
+			for _, ident := range idents {
+				names[ident] = idents
+			}
+
+		case *ast.TypeSpec:
+			names[spec.Name.Name] = []string{spec.Name.Name}
+		}
+	}
+
+	return names
+}
+
+// TypeSpecs returns the *ast.TypeSpec of the given *ast.GenDecl keyed by the type names.
+func TypeSpecs(gdecl *ast.GenDecl) map[string]*ast.TypeSpec {
+	specs := map[string]*ast.TypeSpec{}
+	for _, s := range gdecl.Specs {
+		if spec, ok := s.(*ast.TypeSpec); ok {
+			specs[spec.Name.Name] = spec
+		}
+	}
+
+	return specs
+}
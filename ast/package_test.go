@@ -0,0 +1,101 @@
+package ast_test
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	myAst "github.com/commentcov/commentcov-plugin-go/ast"
+)
+
+// TestPackageHasSymbol is the unittest for Package.HasSymbol.
+func TestPackageHasSymbol(t *testing.T) {
+	src := `package hoge
+
+const MyConst = 1
+
+var myVar string
+
+type MyStruct struct {
+    Field string
+    *Embedded
+}
+
+type MyInterface interface {
+    Method() string
+}
+
+func (s *MyStruct) Method() {}
+
+func (s MyGeneric[T]) GenericMethod() {}
+
+func MyFunc() {}
+`
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "hoge.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg := myAst.NewPackage("hoge", f)
+
+	tests := []struct {
+		name string
+		recv string
+		sym  string
+		want bool
+	}{
+		{name: "const", sym: "MyConst", want: true},
+		{name: "var", sym: "myVar", want: true},
+		{name: "type", sym: "MyStruct", want: true},
+		{name: "func", sym: "MyFunc", want: true},
+		{name: "method is not package level", sym: "Method", want: false},
+		{name: "undeclared", sym: "Undeclared", want: false},
+		{name: "field", recv: "MyStruct", sym: "Field", want: true},
+		{name: "embedded field", recv: "MyStruct", sym: "Embedded", want: true},
+		{name: "method", recv: "MyStruct", sym: "Method", want: true},
+		{name: "interface method", recv: "MyInterface", sym: "Method", want: true},
+		{name: "method of undeclared generic type", recv: "MyGeneric", sym: "GenericMethod", want: false},
+		{name: "undeclared member", recv: "MyStruct", sym: "Undeclared", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := pkg.HasSymbol(tt.recv, tt.sym)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("bool values are mismatch (-want +got):%s\n", diff)
+			}
+		})
+	}
+}
+
+// TestLoadPackage is the unittest for LoadPackage.
+func TestLoadPackage(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.go":      "package hoge\n\nfunc A() {}\n",
+		"b.go":      "package hoge\n\nfunc B() {}\n",
+		"c_test.go": "package hoge\n\nfunc C() {}\n",
+		"d.go":      "package main\n\nfunc D() {}\n",
+		"e.go":      "package hoge\n\nfunc E( {}\n",
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pkg, err := myAst.LoadPackage(token.NewFileSet(), dir, "hoge")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]bool{"A": true, "B": true}
+	if diff := cmp.Diff(want, pkg.Decls); diff != "" {
+		t.Errorf("declaration values are mismatch (-want +got):%s\n", diff)
+	}
+}
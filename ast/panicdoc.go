@@ -0,0 +1,101 @@
+package ast
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"regexp"
+	"strings"
+
+	"github.com/commentcov/commentcov/proto"
+)
+
+// PanicDocCheck is the name of the check for the documentation of the panics.
+const PanicDocCheck = "panic_doc"
+
+// panicWordRe matches the words describing panics.
+var panicWordRe = regexp.MustCompile(`(?i)\bpanic(s|ked|king)?\b`)
+
+// CheckPanicDoc reports the exported function whose body calls panic or Must* helpers
+// but whose header comments do not mention panicking.
+// The Diagnostic is positioned at the first call which may panic.
+func CheckPanicDoc(file string, fset *token.FileSet, fdecl *ast.FuncDecl, ci *proto.CoverageItem) []*Diagnostic {
+	if !ast.IsExported(fdecl.Name.Name) || !isDocumented(ci) {
+		return []*Diagnostic{}
+	}
+
+	call := FindPanicCall(fdecl)
+	if call == nil || panicWordRe.MatchString(commentsText(ci.HeaderComments)) {
+		return []*Diagnostic{}
+	}
+
+	return []*Diagnostic{
+		{
+			Check:      PanicDocCheck,
+			File:       file,
+			Identifier: ci.Identifier,
+			Block:      NewBlock(fset, call.Pos(), call.End()),
+			Message:    fmt.Sprintf("function may panic by calling %s but its comment does not mention panicking", calleeName(call.Fun)),
+		},
+	}
+}
+
+// FindPanicCall returns the first call to panic or Must* helpers in the function body.
+// The calls in the closures are not regarded as the ones of the function.
+func FindPanicCall(fdecl *ast.FuncDecl) *ast.CallExpr {
+	var found *ast.CallExpr
+	if fdecl.Body == nil {
+		return found
+	}
+
+	ast.Inspect(fdecl.Body, func(n ast.Node) bool {
+		if found != nil {
+			return false
+		}
+
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			return false
+
+		case *ast.CallExpr:
+			if name := calleeName(node.Fun); name == "panic" || isMustName(name) {
+				found = node
+				return false
+			}
+		}
+
+		return true
+	})
+
+	return found
+}
+
+// calleeName returns the name of the called function, qualified with the receiver or the package if any.
+func calleeName(fun ast.Expr) string {
+	switch f := fun.(type) {
+	case *ast.Ident:
+		return f.Name
+	case *ast.SelectorExpr:
+		if x := calleeName(f.X); x != "" {
+			return x + "." + f.Sel.Name
+		}
+		return f.Sel.Name
+	case *ast.IndexExpr:
+		return calleeName(f.X)
+	case *ast.IndexListExpr:
+		return calleeName(f.X)
+	}
+
+	return ""
+}
+
+// isMustName returns true if the last element of the callee name follows the naming convention of the Must* helpers,
+// like MustCompile or template.Must.
+func isMustName(name string) bool {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+
+	rest, ok := strings.CutPrefix(name, "Must")
+	return ok && (rest == "" || ast.IsExported(rest))
+}
@@ -0,0 +1,118 @@
+package ast_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/commentcov/commentcov/proto"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	myAst "github.com/commentcov/commentcov-plugin-go/ast"
+)
+
+// TestCheckPanicDoc is the unittest for CheckPanicDoc.
+//
+//nolint:funlen
+func TestCheckPanicDoc(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []*myAst.Diagnostic
+	}{
+		{
+			name: "panic described",
+			src: `package hoge
+
+// MyFunc loads the file. It panics if the file is missing.
+func MyFunc() {
+    panic("missing")
+}
+`,
+			want: []*myAst.Diagnostic{},
+		},
+		{
+			name: "panic not described",
+			src: `package hoge
+
+// MyFunc loads the file.
+func MyFunc() {
+    if missing {
+        panic("missing")
+    }
+}
+`,
+			want: []*myAst.Diagnostic{
+				{
+					Check:      myAst.PanicDocCheck,
+					File:       "hoge.go",
+					Identifier: "MyFunc",
+					Block:      &proto.Block{StartLine: 6, StartColumn: 9, EndLine: 6, EndColumn: 25},
+					Message:    "function may panic by calling panic but its comment does not mention panicking",
+				},
+			},
+		},
+		{
+			name: "must helper not described",
+			src: `package hoge
+
+// MyMethod compiles the pattern.
+func (h *Hoge) MyMethod(p string) *regexp.Regexp {
+    return regexp.MustCompile(p)
+}
+`,
+			want: []*myAst.Diagnostic{
+				{
+					Check:      myAst.PanicDocCheck,
+					File:       "hoge.go",
+					Identifier: "MyMethod",
+					Block:      &proto.Block{StartLine: 5, StartColumn: 12, EndLine: 5, EndColumn: 33},
+					Message:    "function may panic by calling regexp.MustCompile but its comment does not mention panicking",
+				},
+			},
+		},
+		{
+			name: "not checked",
+			src: `package hoge
+
+// myFunc loads the file.
+func myFunc() { panic("x") }
+
+func NoComment() { panic("x") }
+
+// InClosure loads the file.
+func InClosure() {
+    go func() { panic("x") }()
+}
+
+// Mustard is not a Must helper.
+func Mustard() { Mustache() }
+`,
+			want: []*myAst.Diagnostic{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			f, err := parser.ParseFile(fset, "hoge.go", tt.src, parser.ParseComments)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := []*myAst.Diagnostic{}
+			for _, decl := range f.Decls {
+				if d, ok := decl.(*ast.FuncDecl); ok {
+					ci := myAst.ProcessFunctionCoverage("hoge.go", fset, f, d)
+					got = append(got, myAst.CheckPanicDoc("hoge.go", fset, d, ci)...)
+				}
+			}
+
+			if diff := cmp.Diff(tt.want, got, cmpopts.IgnoreUnexported(proto.Block{})); diff != "" {
+				t.Errorf("Diagnostic values are mismatch (-want +got):%s\n", diff)
+			}
+		})
+	}
+}
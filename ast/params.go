@@ -0,0 +1,107 @@
+package ast
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/commentcov/commentcov/proto"
+)
+
+// ParamDocCheck is the name of the check for the parameter documentation coverage.
+const ParamDocCheck = "param_doc"
+
+// ParamCoverage is the documentation coverage of the parameters and the named results of a function.
+type ParamCoverage struct {
+	// Mentioned are the names mentioned in the header comments.
+	Mentioned []string
+	// Missing are the names not mentioned in the header comments.
+	Missing []string
+}
+
+// Total returns the number of the parameters and the named results measured.
+func (pc *ParamCoverage) Total() int {
+	return len(pc.Mentioned) + len(pc.Missing)
+}
+
+// Ratio returns the ratio of the mentioned ones to the total.
+// It returns 1 when there is nothing to be mentioned.
+func (pc *ParamCoverage) Ratio() float64 {
+	if pc.Total() == 0 {
+		return 1
+	}
+
+	return float64(len(pc.Mentioned)) / float64(pc.Total())
+}
+
+// ProcessParamCoverage measures whether the header comments mention each parameter and named result of the function.
+// The receiver, the blank and unnamed ones, and the ones typed with ignoreTypes are excluded.
+func ProcessParamCoverage(fdecl *ast.FuncDecl, hcs []*proto.Comment, ignoreTypes []string) *ParamCoverage {
+	text := commentsText(hcs)
+
+	pc := &ParamCoverage{
+		Mentioned: []string{},
+		Missing:   []string{},
+	}
+
+	fields := []*ast.Field{}
+	if fdecl.Type.Params != nil {
+		fields = append(fields, fdecl.Type.Params.List...)
+	}
+	if fdecl.Type.Results != nil {
+		fields = append(fields, fdecl.Type.Results.List...)
+	}
+
+	for _, field := range fields {
+		if slices.Contains(ignoreTypes, types.ExprString(field.Type)) {
+			continue
+		}
+
+		for _, name := range field.Names {
+			if name.Name == "_" {
+				continue
+			}
+
+			if IsMentioned(text, name.Name) {
+				pc.Mentioned = append(pc.Mentioned, name.Name)
+			} else {
+				pc.Missing = append(pc.Missing, name.Name)
+			}
+		}
+	}
+
+	return pc
+}
+
+// IsMentioned returns true if the given text contains the given name as a whole word.
+func IsMentioned(text, name string) bool {
+	re := regexp.MustCompile(`(^|[^\pL\pN_])` + regexp.QuoteMeta(name) + `($|[^\pL\pN_])`)
+	return re.MatchString(text)
+}
+
+// CheckParamDoc reports the function whose parameter documentation coverage is lower than minRatio.
+// The functions without HeaderComments are not checked, and the check is disabled if minRatio is 0.
+func CheckParamDoc(
+	file string, fset *token.FileSet, fdecl *ast.FuncDecl, ci *proto.CoverageItem, pc *ParamCoverage, minRatio float64,
+) []*Diagnostic {
+	if minRatio <= 0 || len(ci.HeaderComments) == 0 || pc.Ratio() >= minRatio {
+		return []*Diagnostic{}
+	}
+
+	return []*Diagnostic{
+		{
+			Check:      ParamDocCheck,
+			File:       file,
+			Identifier: ci.Identifier,
+			Block:      NewBlock(fset, fdecl.Name.Pos(), fdecl.Name.End()),
+			Message: fmt.Sprintf(
+				"comment mentions %d of %d parameters, missing %s",
+				len(pc.Mentioned), pc.Total(), strings.Join(pc.Missing, ", "),
+			),
+		},
+	}
+}
@@ -0,0 +1,153 @@
+package ast_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	myAst "github.com/commentcov/commentcov-plugin-go/ast"
+)
+
+// TestProcessParamCoverage is the unittest for ProcessParamCoverage.
+func TestProcessParamCoverage(t *testing.T) {
+	tests := []struct {
+		name        string
+		src         string
+		ignoreTypes []string
+		want        *myAst.ParamCoverage
+	}{
+		{
+			name: "all params mentioned",
+			src: `package hoge
+
+// MyFunc joins a and b.
+func MyFunc(a, b string) string {
+    return a + b
+}
+`,
+			want: &myAst.ParamCoverage{
+				Mentioned: []string{"a", "b"},
+				Missing:   []string{},
+			},
+		},
+		{
+			name: "params and named results partially mentioned",
+			src: `package hoge
+
+// MyFunc returns n copies of s.
+func MyFunc(s string, n int, sep string) (joined string, err error) {
+    return "", nil
+}
+`,
+			want: &myAst.ParamCoverage{
+				Mentioned: []string{"s", "n"},
+				Missing:   []string{"sep", "joined", "err"},
+			},
+		},
+		{
+			name: "name as a part of other word is not mentioned",
+			src: `package hoge
+
+// MyFunc handles the identifier.
+func MyFunc(id string) {}
+`,
+			want: &myAst.ParamCoverage{
+				Mentioned: []string{},
+				Missing:   []string{"id"},
+			},
+		},
+		{
+			name: "receiver, blank and unnamed are excluded",
+			src: `package hoge
+
+// MyFunc does nothing.
+func (h *Hoge) MyFunc(_ string, x int) error {
+    return nil
+}
+`,
+			want: &myAst.ParamCoverage{
+				Mentioned: []string{},
+				Missing:   []string{"x"},
+			},
+		},
+		{
+			name: "ignored types are excluded",
+			src: `package hoge
+
+// MyFunc fetches the key.
+func MyFunc(ctx context.Context, key string) {}
+`,
+			ignoreTypes: []string{"context.Context"},
+			want: &myAst.ParamCoverage{
+				Mentioned: []string{"key"},
+				Missing:   []string{},
+			},
+		},
+		{
+			name: "no comment",
+			src: `package hoge
+
+func MyFunc(a string) {}
+`,
+			want: &myAst.ParamCoverage{
+				Mentioned: []string{},
+				Missing:   []string{"a"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			f, err := parser.ParseFile(fset, "hoge.go", tt.src, parser.ParseComments)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, decl := range f.Decls {
+				if d, ok := decl.(*ast.FuncDecl); ok {
+					ci := myAst.ProcessFunctionCoverage("hoge.go", fset, f, d)
+					got := myAst.ProcessParamCoverage(d, ci.HeaderComments, tt.ignoreTypes)
+					if diff := cmp.Diff(tt.want, got); diff != "" {
+						t.Errorf("ParamCoverage values are mismatch (-want +got):%s\n", diff)
+					}
+				}
+			}
+		})
+	}
+}
+
+// TestParamCoverageRatio is the unittest for ParamCoverage.Ratio.
+func TestParamCoverageRatio(t *testing.T) {
+	tests := []struct {
+		name string
+		pc   *myAst.ParamCoverage
+		want float64
+	}{
+		{
+			name: "nothing to be mentioned",
+			pc:   &myAst.ParamCoverage{},
+			want: 1,
+		},
+		{
+			name: "half mentioned",
+			pc: &myAst.ParamCoverage{
+				Mentioned: []string{"a"},
+				Missing:   []string{"b"},
+			},
+			want: 0.5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.pc.Ratio()
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("float64 values are mismatch (-want +got):%s\n", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package ast
+
+import (
+	"github.com/commentcov/commentcov/proto"
+)
+
+// The kinds of the CoverageItems toggled by Options.Kinds.
+const (
+	// KindPackage is the kind of the package comments.
+	KindPackage = "package"
+	// KindFunction is the kind of the functions.
+	KindFunction = "function"
+	// KindMethod is the kind of the methods.
+	KindMethod = "method"
+	// KindType is the kind of the types.
+	KindType = "type"
+	// KindVariable is the kind of the constants and the variables.
+	KindVariable = "variable"
+	// KindField is the kind of the fields of the struct types. It is not measured unless enabled.
+	KindField = "field"
+	// KindPrivate is not a kind of the declarations but the private items of all the kinds except KindPackage.
+	KindPrivate = "private"
+)
+
+// Kinds are the names of all the kinds.
+var Kinds = []string{
+	KindPackage,
+	KindFunction,
+	KindMethod,
+	KindType,
+	KindVariable,
+	KindField,
+	KindPrivate,
+}
+
+// IsKindEnabled returns true if the kind is measured by the Options.
+// The kinds not listed in Kinds are measured except KindField.
+func (o *Options) IsKindEnabled(kind string) bool {
+	if enabled, ok := o.Kinds[kind]; ok {
+		return enabled
+	}
+
+	return kind != KindField
+}
+
+// isMeasured returns true if the CoverageItem of the kind is measured by the Options.
+func (o *Options) isMeasured(kind string, ci *proto.CoverageItem) bool {
+	if !o.IsKindEnabled(kind) {
+		return false
+	}
+
+	return kind == KindPackage || o.IsKindEnabled(KindPrivate) || !IsPrivateScope(ci.Scope)
+}
+
+// kindOf returns the kind of the CoverageItem of a GenDecl.
+//
+//nolint:exhaustive
+func kindOf(ci *proto.CoverageItem) string {
+	switch ci.Scope {
+	case proto.CoverageItem_PUBLIC_CLASS, proto.CoverageItem_PRIVATE_CLASS,
+		proto.CoverageItem_PUBLIC_TYPE, proto.CoverageItem_PRIVATE_TYPE:
+		return KindType
+	}
+
+	return KindVariable
+}
@@ -0,0 +1,210 @@
+package ast_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/commentcov/commentcov/proto"
+	"github.com/google/go-cmp/cmp"
+
+	myAst "github.com/commentcov/commentcov-plugin-go/ast"
+)
+
+// TestAnalyze_Kinds is the unittest for Analyzer.Analyze with Options.Kinds.
+//
+//nolint:funlen
+func TestAnalyze_Kinds(t *testing.T) {
+	src := `// Package hoge is a package.
+package hoge
+
+// Config is the config.
+type Config struct {
+	// Name is the name.
+	Name string
+	Debug bool // Debug enables the debug log.
+	io.Reader
+	secret string
+}
+
+type config struct {
+	Value int
+}
+
+// Celsius is the temperature.
+type Celsius float64
+
+// Run runs.
+func (c *Config) Run() {}
+
+// New returns the Config.
+func New() *Config {}
+
+func helper() {}
+
+// Version is the version.
+const Version = "v1"
+`
+
+	tests := []struct {
+		name  string
+		kinds map[string]bool
+		want  map[string]string
+	}{
+		{
+			name:  "default",
+			kinds: nil,
+			want: map[string]string{
+				"hoge":    myAst.KindPackage,
+				"Config":  myAst.KindType,
+				"config":  myAst.KindType,
+				"Celsius": myAst.KindType,
+				"Run":     myAst.KindMethod,
+				"New":     myAst.KindFunction,
+				"helper":  myAst.KindFunction,
+				"Version": myAst.KindVariable,
+			},
+		},
+		{
+			name:  "exported fields",
+			kinds: map[string]bool{myAst.KindField: true, myAst.KindPrivate: false},
+			want: map[string]string{
+				"hoge":          myAst.KindPackage,
+				"Config":        myAst.KindType,
+				"Config.Name":   myAst.KindField,
+				"Config.Debug":  myAst.KindField,
+				"Config.Reader": myAst.KindField,
+				"Celsius":       myAst.KindType,
+				"Run":           myAst.KindMethod,
+				"New":           myAst.KindFunction,
+				"Version":       myAst.KindVariable,
+			},
+		},
+		{
+			name:  "types off",
+			kinds: map[string]bool{myAst.KindType: false},
+			want: map[string]string{
+				"hoge":    myAst.KindPackage,
+				"Run":     myAst.KindMethod,
+				"New":     myAst.KindFunction,
+				"helper":  myAst.KindFunction,
+				"Version": myAst.KindVariable,
+			},
+		},
+		{
+			name: "package only",
+			kinds: map[string]bool{
+				myAst.KindFunction: false,
+				myAst.KindMethod:   false,
+				myAst.KindType:     false,
+				myAst.KindVariable: false,
+			},
+			want: map[string]string{
+				"hoge": myAst.KindPackage,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			f, err := parser.ParseFile(fset, "hoge.go", src, parser.ParseComments)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			options := myAst.DefaultOptions()
+			options.Kinds = tt.kinds
+			options.Rule = "api"
+			res := myAst.NewAnalyzer(options).Analyze("hoge.go", fset, f, myAst.NewPackage("hoge", f))
+
+			got := map[string]string{}
+			for _, ci := range res.Items {
+				got[ci.Identifier] = res.Details[ci].Kind
+
+				if diff := cmp.Diff("api", res.Details[ci].Rule); diff != "" {
+					t.Errorf("Rule values are mismatch (-want +got):%s\n", diff)
+				}
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Kind values are mismatch (-want +got):%s\n", diff)
+			}
+		})
+	}
+}
+
+// TestProcessFieldCoverage is the unittest for ProcessFieldCoverage.
+func TestProcessFieldCoverage(t *testing.T) {
+	src := `package hoge
+
+type Config struct {
+	// Name is the name.
+	Name string
+	Debug bool // Debug enables the debug log.
+	A, b int
+}
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "hoge.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ts := myAst.TypeSpecs(f.Decls[0].(*ast.GenDecl))["Config"]
+	got := myAst.ProcessFieldCoverage("hoge.go", fset, f, ts)
+
+	want := []*proto.CoverageItem{
+		{
+			Scope:       proto.CoverageItem_PUBLIC_VARIABLE,
+			TargetBlock: &proto.Block{StartLine: 5, StartColumn: 2, EndLine: 5, EndColumn: 13},
+			File:        "hoge.go",
+			Identifier:  "Config.Name",
+			Extension:   ".go",
+			HeaderComments: []*proto.Comment{
+				{
+					Comment: "Name is the name.\n",
+					Block:   &proto.Block{StartLine: 4, StartColumn: 2, EndLine: 4, EndColumn: 22},
+				},
+			},
+			InlineComments: []*proto.Comment{},
+		},
+		{
+			Scope:          proto.CoverageItem_PUBLIC_VARIABLE,
+			TargetBlock:    &proto.Block{StartLine: 6, StartColumn: 2, EndLine: 6, EndColumn: 12},
+			File:           "hoge.go",
+			Identifier:     "Config.Debug",
+			Extension:      ".go",
+			HeaderComments: []*proto.Comment{},
+			InlineComments: []*proto.Comment{
+				{
+					Comment: "Debug enables the debug log.\n",
+					Block:   &proto.Block{StartLine: 6, StartColumn: 13, EndLine: 6, EndColumn: 44},
+				},
+			},
+		},
+		{
+			Scope:          proto.CoverageItem_PUBLIC_VARIABLE,
+			TargetBlock:    &proto.Block{StartLine: 7, StartColumn: 2, EndLine: 7, EndColumn: 10},
+			File:           "hoge.go",
+			Identifier:     "Config.A",
+			Extension:      ".go",
+			HeaderComments: []*proto.Comment{},
+			InlineComments: []*proto.Comment{},
+		},
+		{
+			Scope:          proto.CoverageItem_PRIVATE_VARIABLE,
+			TargetBlock:    &proto.Block{StartLine: 7, StartColumn: 2, EndLine: 7, EndColumn: 10},
+			File:           "hoge.go",
+			Identifier:     "Config.b",
+			Extension:      ".go",
+			HeaderComments: []*proto.Comment{},
+			InlineComments: []*proto.Comment{},
+		},
+	}
+
+	if diff := cmp.Diff(want, got, coverageItemCmp); diff != "" {
+		t.Errorf("ProcessFieldCoverage() values are mismatch (-want +got):%s\n", diff)
+	}
+}
@@ -0,0 +1,199 @@
+package ast
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// reachability computes the identifiers reachable from the exported API of a package.
+type reachability struct {
+	types     map[string]*ast.TypeSpec
+	funcs     map[string]*ast.FuncDecl
+	methods   map[string][]*ast.FuncDecl
+	reachable map[string]bool
+	queue     []string
+}
+
+// Reachable returns the identifiers reachable from outside of the package through its exported API.
+// The exported package level identifiers are the roots, and the types are reachable through
+// the signatures of the functions and the methods, the types of the variables, the exported and the embedded fields,
+// and the methods of the interfaces. The exported methods of the reachable types are reachable as well.
+// The methods are keyed by ReachabilityKey.
+func Reachable(pkg *Package) map[string]bool {
+	r := &reachability{
+		types:     map[string]*ast.TypeSpec{},
+		funcs:     map[string]*ast.FuncDecl{},
+		methods:   map[string][]*ast.FuncDecl{},
+		reachable: map[string]bool{},
+		queue:     []string{},
+	}
+
+	values := []*ast.ValueSpec{}
+	for _, f := range pkg.Files {
+		for _, decl := range f.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				if recv := ReceiverTypeName(d); recv != "" {
+					r.methods[recv] = append(r.methods[recv], d)
+				} else if d.Recv == nil {
+					r.funcs[d.Name.Name] = d
+				}
+
+			case *ast.GenDecl:
+				for _, s := range d.Specs {
+					switch spec := s.(type) {
+					case *ast.TypeSpec:
+						r.types[spec.Name.Name] = spec
+					case *ast.ValueSpec:
+						values = append(values, spec)
+					}
+				}
+			}
+		}
+	}
+
+	for name, fdecl := range r.funcs {
+		if ast.IsExported(name) {
+			r.reachable[name] = true
+			r.walk(fdecl.Type)
+		}
+	}
+
+	for name := range r.types {
+		if ast.IsExported(name) {
+			r.markType(name)
+		}
+	}
+
+	for _, spec := range values {
+		for i, name := range spec.Names {
+			if !ast.IsExported(name.Name) {
+				continue
+			}
+
+			r.reachable[name.Name] = true
+			if spec.Type != nil {
+				r.walk(spec.Type)
+			} else if i < len(spec.Values) {
+				r.walkValue(spec.Values[i])
+			}
+		}
+	}
+
+	for len(r.queue) > 0 {
+		name := r.queue[0]
+		r.queue = r.queue[1:]
+		r.visitType(name)
+	}
+
+	return r.reachable
+}
+
+// ReachabilityKey returns the key of the function or the method in the set returned by Reachable.
+func ReachabilityKey(recv, name string) string {
+	if recv == "" {
+		return name
+	}
+
+	return recv + "." + name
+}
+
+// markType marks the type declared in the package as reachable and queues it to be visited.
+func (r *reachability) markType(name string) {
+	if _, ok := r.types[name]; !ok || r.reachable[name] {
+		return
+	}
+
+	r.reachable[name] = true
+	r.queue = append(r.queue, name)
+}
+
+// visitType marks the types reachable through the given type and its exported methods.
+func (r *reachability) visitType(name string) {
+	spec := r.types[name]
+	if spec.TypeParams != nil {
+		r.walk(spec.TypeParams)
+	}
+
+	switch t := spec.Type.(type) {
+	case *ast.StructType:
+		r.walkFields(t.Fields)
+	case *ast.InterfaceType:
+		r.walkFields(t.Methods)
+	default:
+		r.walk(t)
+	}
+
+	for _, m := range r.methods[name] {
+		if ast.IsExported(m.Name.Name) {
+			r.reachable[ReachabilityKey(name, m.Name.Name)] = true
+			r.walk(m.Type)
+		}
+	}
+}
+
+// walkFields walks the types of the exported and the embedded fields.
+func (r *reachability) walkFields(fields *ast.FieldList) {
+	for _, field := range fields.List {
+		if len(field.Names) == 0 {
+			r.walk(field.Type)
+			continue
+		}
+
+		for _, name := range field.Names {
+			if ast.IsExported(name.Name) {
+				r.walk(field.Type)
+				break
+			}
+		}
+	}
+}
+
+// walk marks the types of the package referred in the given type expression.
+func (r *reachability) walk(node ast.Node) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch x := n.(type) {
+		case *ast.Field:
+			// the names of the parameters are not types.
+			r.walk(x.Type)
+			return false
+		case *ast.SelectorExpr:
+			// the types of the other packages.
+			return false
+		case *ast.Ident:
+			r.markType(x.Name)
+		}
+
+		return true
+	})
+}
+
+// walkValue marks the type of the value inferred from the initializer, without type checking.
+func (r *reachability) walkValue(expr ast.Expr) {
+	switch v := expr.(type) {
+	case *ast.CompositeLit:
+		r.walk(v.Type)
+	case *ast.UnaryExpr:
+		if v.Op == token.AND {
+			r.walkValue(v.X)
+		}
+	case *ast.ParenExpr:
+		r.walkValue(v.X)
+	case *ast.CallExpr:
+		ident, ok := v.Fun.(*ast.Ident)
+		if !ok {
+			return
+		}
+
+		if fdecl, ok := r.funcs[ident.Name]; ok {
+			if fdecl.Type.Results != nil {
+				r.walk(fdecl.Type.Results)
+			}
+
+			return
+		}
+
+		// conversion.
+		r.markType(ident.Name)
+	}
+}
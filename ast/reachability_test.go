@@ -0,0 +1,131 @@
+package ast_test
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/commentcov/commentcov/proto"
+	"github.com/google/go-cmp/cmp"
+
+	myAst "github.com/commentcov/commentcov-plugin-go/ast"
+)
+
+// TestReachable is the unittest for Reachable.
+func TestReachable(t *testing.T) {
+	src := `package hoge
+
+import "io"
+
+// NewClient returns the unexported client.
+func NewClient(o *options) *client { return &client{} }
+
+type client struct {
+	base
+	conn conn
+}
+
+func (c *client) Do() result { return result{} }
+
+func (c *client) do() {}
+
+type base struct{}
+
+func (b base) Close() error { return nil }
+
+type options struct{}
+
+type conn struct{}
+
+type result struct{}
+
+type unused struct{}
+
+func (u unused) Exported() {}
+
+// Default is the default handler.
+var Default = newHandler()
+
+func newHandler() *handler { return nil }
+
+type handler struct{}
+
+// Reader wraps io.Reader.
+type Reader interface {
+	io.Reader
+	Peek() peeked
+}
+
+type peeked []byte
+`
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "hoge.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]bool{
+		"NewClient":  true,
+		"client":     true,
+		"client.Do":  true,
+		"base":       true,
+		"base.Close": true,
+		"options":    true,
+		"result":     true,
+		"Default":    true,
+		"handler":    true,
+		"Reader":     true,
+		"peeked":     true,
+	}
+
+	got := myAst.Reachable(myAst.NewPackage("hoge", f))
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("reachable identifiers are mismatch (-want +got):%s\n", diff)
+	}
+}
+
+// TestAnalyze_Reachability is the unittest for Analyzer.Analyze with Options.Reachability.
+func TestAnalyze_Reachability(t *testing.T) {
+	src := `package hoge
+
+// New returns the unexported client.
+func New() *client { return &client{} }
+
+type client struct{}
+
+func (c *client) Do() {}
+
+type unused struct{}
+
+func (u unused) Exported() {}
+`
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "hoge.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	options := myAst.DefaultOptions()
+	options.Reachability = true
+	res := myAst.NewAnalyzer(options).Analyze("hoge.go", fset, f, myAst.NewPackage("hoge", f))
+
+	want := map[string]proto.CoverageItem_Scope{
+		"hoge":     proto.CoverageItem_FILE,
+		"New":      proto.CoverageItem_PUBLIC_FUNCTION,
+		"client":   proto.CoverageItem_PUBLIC_CLASS,
+		"Do":       proto.CoverageItem_PUBLIC_FUNCTION,
+		"unused":   proto.CoverageItem_PRIVATE_CLASS,
+		"Exported": proto.CoverageItem_PRIVATE_FUNCTION,
+	}
+
+	got := map[string]proto.CoverageItem_Scope{}
+	for _, ci := range res.Items {
+		got[ci.Identifier] = ci.Scope
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Scope values are mismatch (-want +got):%s\n", diff)
+	}
+}
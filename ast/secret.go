@@ -0,0 +1,204 @@
+package ast
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/commentcov/commentcov/proto"
+)
+
+// SecretCheck is the name of the check for the secrets written in comments.
+const SecretCheck = "secret"
+
+// secretRules are the known patterns of the secrets.
+// If a pattern has a capturing group, only the group is regarded as the secret.
+var secretRules = []struct {
+	name string
+	re   *regexp.Regexp
+}{
+	{"private key", regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY( BLOCK)?-----`)},
+	{"AWS access key ID", regexp.MustCompile(`\b((?:AKIA|ASIA)[0-9A-Z]{16})\b`)},
+	{"AWS secret access key", regexp.MustCompile(`(?i)aws.{0,20}secret.{0,20}[:=]\s*["']?([A-Za-z0-9/+=]{40})\b`)},
+	{"JWT", regexp.MustCompile(`\b(eyJ[A-Za-z0-9_-]{4,}\.eyJ[A-Za-z0-9_-]{4,}\.[A-Za-z0-9_-]{4,})`)},
+	{"GitHub token", regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{36})\b`)},
+	{"password", regexp.MustCompile(`(?i)\b(?:password|passwd|pwd|secret|api_?key|token)(?:\s*=\s*["']?|:\s*["']|:)([^\s"',;]{4,})`)},
+	{"internal hostname", regexp.MustCompile(`\b((?:[a-z0-9-]+\.)+(?:internal|corp|intranet|lan|local))\b`)},
+}
+
+var (
+	// entropyCandidateRe matches the tokens which may be random secrets.
+	entropyCandidateRe = regexp.MustCompile(`[A-Za-z0-9+/=_-]{20,}`)
+
+	// placeholderRe matches the values which are obviously not secrets, like `password=<your password>` or `token=${TOKEN}`.
+	placeholderRe = regexp.MustCompile(`^(?:[<${%].*|x+|\*+|\.+|(?i:changeme|example|dummy|secret|password|redacted))$`)
+)
+
+// trailingPunctuation are the characters trimmed from the end of the secrets.
+const trailingPunctuation = ".:!?)"
+
+// minSecretEntropy is the minimum Shannon entropy in bits per character of a token regarded as a random secret.
+const minSecretEntropy = 4.0
+
+// revealedSecretLength is the number of the leading characters of a secret kept by Redact.
+const revealedSecretLength = 4
+
+// Secret is a secret-like string found in a comment.
+type Secret struct {
+	// Rule is the name of the rule which found the secret.
+	Rule string
+	// Offset is the byte offset of the secret in the text.
+	Offset int
+	// Value is the secret as it is. It must not be reported, use Redact instead.
+	Value string
+}
+
+// FindSecrets returns the secrets found in the given text by the known patterns and the entropy of the tokens.
+// The secrets overlapping the ones already found are omitted.
+func FindSecrets(text string) []*Secret {
+	secrets := []*Secret{}
+	overlaps := func(start, end int) bool {
+		for _, s := range secrets {
+			if start < s.Offset+len(s.Value) && s.Offset < end {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for _, rule := range secretRules {
+		for _, m := range rule.re.FindAllStringSubmatchIndex(text, -1) {
+			start, end := m[0], m[1]
+			if len(m) > 2 && m[2] >= 0 {
+				start, end = m[2], m[3]
+			}
+
+			// the punctuation ending the sentence is not a part of the secret.
+			for end > start && strings.ContainsRune(trailingPunctuation, rune(text[end-1])) {
+				end--
+			}
+
+			if placeholderRe.MatchString(text[start:end]) || overlaps(start, end) {
+				continue
+			}
+
+			secrets = append(secrets, &Secret{Rule: rule.name, Offset: start, Value: text[start:end]})
+		}
+	}
+
+	for _, m := range entropyCandidateRe.FindAllStringIndex(text, -1) {
+		value := text[m[0]:m[1]]
+		if !isRandomLooking(value) || overlaps(m[0], m[1]) {
+			continue
+		}
+
+		secrets = append(secrets, &Secret{Rule: "high entropy string", Offset: m[0], Value: value})
+	}
+
+	return secrets
+}
+
+// isRandomLooking returns true if the given token mixes letters and digits and its entropy is high enough.
+func isRandomLooking(token string) bool {
+	letter := false
+	digit := false
+	for _, r := range token {
+		letter = letter || unicode.IsLetter(r)
+		digit = digit || unicode.IsDigit(r)
+	}
+
+	return letter && digit && Entropy(token) >= minSecretEntropy
+}
+
+// Entropy returns the Shannon entropy of the given string in bits per character.
+func Entropy(str string) float64 {
+	if str == "" {
+		return 0
+	}
+
+	freq := map[rune]int{}
+	n := 0
+	for _, r := range str {
+		freq[r]++
+		n++
+	}
+
+	e := 0.0
+	for _, c := range freq {
+		p := float64(c) / float64(n)
+		e -= p * math.Log2(p)
+	}
+
+	return e
+}
+
+// Redact masks the given secret except the few leading characters, keeping its length.
+func Redact(secret string) string {
+	runes := []rune(secret)
+	n := revealedSecretLength
+	if len(runes) <= n*2 {
+		n = 0
+	}
+
+	return string(runes[:n]) + strings.Repeat("*", len(runes)-n)
+}
+
+// CommentSecrets returns the secrets found by FindSecrets in each comment of the file.
+// The comments without secrets are omitted.
+func CommentSecrets(f *ast.File) map[*ast.Comment][]*Secret {
+	secrets := map[*ast.Comment][]*Secret{}
+	for _, cg := range f.Comments {
+		for _, c := range cg.List {
+			if ss := FindSecrets(c.Text); len(ss) > 0 {
+				secrets[c] = ss
+			}
+		}
+	}
+
+	return secrets
+}
+
+// RedactSecrets returns the text with all the occurrences of the secrets replaced by their Redact.
+func RedactSecrets(text string, secrets []*Secret) string {
+	for _, s := range secrets {
+		text = strings.ReplaceAll(text, s.Value, Redact(s.Value))
+	}
+
+	return text
+}
+
+// CheckSecrets reports the secrets of the comments found by CommentSecrets, with the redacted snippets only.
+// Each Diagnostic is attributed to the innermost CoverageItem which the comment group belongs to as a header or an inline comment.
+func CheckSecrets(
+	file string, fset *token.FileSet, f *ast.File, items []*proto.CoverageItem, secrets map[*ast.Comment][]*Secret,
+) []*Diagnostic {
+	ds := []*Diagnostic{}
+	for _, cg := range f.Comments {
+		identifier := ""
+		for _, ci := range items {
+			if IsHeader(fset, cg, ci.TargetBlock) || IsInline(fset, cg, ci.TargetBlock) {
+				identifier = ci.Identifier
+			}
+		}
+
+		for _, c := range cg.List {
+			for _, s := range secrets[c] {
+				pos := c.Slash + token.Pos(s.Offset)
+				ds = append(ds, &Diagnostic{
+					Check:      SecretCheck,
+					File:       file,
+					Identifier: identifier,
+					Block:      NewBlock(fset, pos, pos+token.Pos(len(s.Value))),
+					Message:    fmt.Sprintf("comment may contain a secret (%s): %s", s.Rule, Redact(s.Value)),
+				})
+			}
+		}
+	}
+
+	return ds
+}
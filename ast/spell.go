@@ -0,0 +1,204 @@
+package ast
+
+//go:generate go run gen_words.go
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"go/ast"
+	"go/doc/comment"
+	"go/token"
+	"os"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/commentcov/commentcov/proto"
+)
+
+// SpellCheck is the name of the check for the misspellings.
+const SpellCheck = "spell"
+
+// wordsTxt is the built-in English word list generated by gen_words.go,
+// which is the general English vocabulary with the terms of the Go standard library on top of it.
+//
+//go:embed words.txt
+var wordsTxt string
+
+var (
+	// spellWordRe matches the tokens to be spell checked.
+	spellWordRe = regexp.MustCompile(`[\pL\pN_]+(?:'[\pL]+)?`)
+
+	// codeSpanRe matches the code spans quoted by backquotes.
+	codeSpanRe = regexp.MustCompile("`[^`]*`")
+
+	// urlRe matches the URLs which are not recognized as links by go/doc/comment.
+	urlRe = regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://\S+`)
+
+	// inflections are the suffixes tried to be removed to find the word in the dictionary.
+	inflections = []string{"s", "es", "ed", "d", "ing", "ly", "er", "est", "'s"}
+)
+
+// Dictionary is the set of the words regarded as correctly spelled.
+type Dictionary map[string]bool
+
+// BuiltinDictionary returns the Dictionary of the built-in English word list.
+func BuiltinDictionary() Dictionary {
+	d := Dictionary{}
+	d.AddWords(strings.Fields(wordsTxt)...)
+
+	return d
+}
+
+// LoadDictionary loads the project dictionary file to the Dictionary.
+// The file lists a word per line, and the lines starting with # are ignored.
+func (d Dictionary) LoadDictionary(path string) error {
+	fp, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer fp.Close()
+
+	scanner := bufio.NewScanner(fp)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		d.AddWords(line)
+	}
+
+	return scanner.Err()
+}
+
+// AddWords adds the words to the Dictionary case-insensitively.
+func (d Dictionary) AddWords(words ...string) {
+	for _, w := range words {
+		d[strings.ToLower(w)] = true
+	}
+}
+
+// Contains returns true if the word or its stem without the inflectional suffix is in the Dictionary.
+func (d Dictionary) Contains(word string) bool {
+	w := strings.ToLower(word)
+	if d[w] {
+		return true
+	}
+
+	for _, suffix := range inflections {
+		stem, ok := strings.CutSuffix(w, suffix)
+		if !ok || stem == "" {
+			continue
+		}
+
+		for _, s := range stemSpellings(stem) {
+			if d[s] {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// stemSpellings returns the candidates of the base word for the stem without the inflectional suffix.
+// They undo the spelling changes by the inflections, like "making", "running" and "companies".
+func stemSpellings(stem string) []string {
+	candidates := []string{stem, stem + "e"}
+	if s, ok := strings.CutSuffix(stem, "i"); ok {
+		candidates = append(candidates, s+"y")
+	}
+	if n := len(stem); n >= 2 && stem[n-1] == stem[n-2] {
+		candidates = append(candidates, stem[:n-1])
+	}
+
+	return candidates
+}
+
+// FileIdentifiers returns the Dictionary of the identifiers used in the file.
+func FileIdentifiers(f *ast.File) Dictionary {
+	d := Dictionary{}
+	ast.Inspect(f, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok {
+			d.AddWords(ident.Name)
+		}
+
+		return true
+	})
+
+	for name := range ImportNames(f) {
+		d.AddWords(name)
+	}
+
+	return d
+}
+
+// CheckSpelling reports the words in the header comments of the CoverageItem which are in none of the dictionaries.
+// The code blocks, the code spans, the URLs and the doc links are skipped,
+// as well as the tokens looking like identifiers or acronyms, which contain digits, underscores or uppercase letters in the middle.
+func CheckSpelling(file string, fset *token.FileSet, f *ast.File, ci *proto.CoverageItem, dicts ...Dictionary) []*Diagnostic {
+	imports := ImportNames(f)
+
+	// the links are parsed as the doc links regardless of whether they resolve, which is the doc_link check's concern.
+	p := comment.Parser{
+		LookupPackage: func(name string) (string, bool) {
+			importPath, ok := imports[name]
+			return importPath, ok
+		},
+		LookupSym: func(_, _ string) bool {
+			return true
+		},
+	}
+
+	ds := []*Diagnostic{}
+	for _, cg := range HeaderCommentGroups(fset, f, ci.TargetBlock) {
+		doc := p.Parse(NormalizeGroup(cg))
+
+		reported := map[string]bool{}
+		for _, text := range docPlainTexts(doc) {
+			text = codeSpanRe.ReplaceAllString(text, " ")
+			text = urlRe.ReplaceAllString(text, " ")
+
+			for _, word := range spellWordRe.FindAllString(text, -1) {
+				if reported[word] || !isSpellCheckable(word) || containsWord(word, dicts) {
+					continue
+				}
+
+				reported[word] = true
+				ds = append(ds, &Diagnostic{
+					Check:      SpellCheck,
+					File:       file,
+					Identifier: ci.Identifier,
+					Block:      FindWordInCommentGroup(fset, cg, word),
+					Message:    fmt.Sprintf("%s is misspelled", word),
+				})
+			}
+		}
+	}
+
+	return ds
+}
+
+// isSpellCheckable returns true if the token is an English word rather than an identifier or an acronym.
+func isSpellCheckable(word string) bool {
+	for i, r := range word {
+		if unicode.IsDigit(r) || r == '_' || (i > 0 && unicode.IsUpper(r)) {
+			return false
+		}
+	}
+
+	return len(word) > 1
+}
+
+// containsWord returns true if any of the dictionaries contains the word.
+func containsWord(word string, dicts []Dictionary) bool {
+	for _, d := range dicts {
+		if d.Contains(word) {
+			return true
+		}
+	}
+
+	return false
+}
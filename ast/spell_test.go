@@ -0,0 +1,192 @@
+package ast_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/commentcov/commentcov/proto"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	myAst "github.com/commentcov/commentcov-plugin-go/ast"
+)
+
+// TestCheckSpelling is the unittest for CheckSpelling.
+//
+//nolint:funlen
+func TestCheckSpelling(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		project []string
+		want    []*myAst.Diagnostic
+	}{
+		{
+			name: "misspellings",
+			src: `package hoge
+
+// MyFunc recieves the value and returns teh result.
+// It never recieves nil.
+func MyFunc() {}
+`,
+			want: []*myAst.Diagnostic{
+				{
+					Check:      myAst.SpellCheck,
+					File:       "hoge.go",
+					Identifier: "MyFunc",
+					Block: &proto.Block{
+						StartLine:   3,
+						StartColumn: 11,
+						EndLine:     3,
+						EndColumn:   19,
+					},
+					Message: "recieves is misspelled",
+				},
+				{
+					Check:      myAst.SpellCheck,
+					File:       "hoge.go",
+					Identifier: "MyFunc",
+					Block: &proto.Block{
+						StartLine:   3,
+						StartColumn: 42,
+						EndLine:     3,
+						EndColumn:   45,
+					},
+					Message: "teh is misspelled",
+				},
+			},
+		},
+		{
+			name: "skipped texts",
+			src: `package hoge
+
+import "github.com/hoge/fugalib"
+
+// MyFunc calls fugalib with the piyoValue of [MyType] using ` + "`qwrt`" + `.
+// See https://example.com/xyzzy and HTTP_PROXY.
+//
+//	zzyzx := 1
+func MyFunc(piyoValue int) {}
+
+type MyType struct{}
+`,
+			want: []*myAst.Diagnostic{},
+		},
+		{
+			name: "doc links",
+			src: `package hoge
+
+import "github.com/hoge/fugalib"
+
+// MyFunc uses [Frobnicator] and [Quuxer.Zorp] of [fugalib.Blorp].
+func MyFunc() {}
+`,
+			want: []*myAst.Diagnostic{},
+		},
+		{
+			name: "project dictionary",
+			src: `package hoge
+
+// MyFunc calls commentcov.
+func MyFunc() {}
+`,
+			project: []string{"commentcov"},
+			want:    []*myAst.Diagnostic{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			f, err := parser.ParseFile(fset, "hoge.go", tt.src, parser.ParseComments)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			project := myAst.Dictionary{}
+			project.AddWords(tt.project...)
+
+			got := []*myAst.Diagnostic{}
+			for _, decl := range f.Decls {
+				if d, ok := decl.(*ast.FuncDecl); ok {
+					ci := myAst.ProcessFunctionCoverage("hoge.go", fset, f, d)
+					got = append(got, myAst.CheckSpelling("hoge.go", fset, f, ci, myAst.BuiltinDictionary(), myAst.FileIdentifiers(f), project)...)
+				}
+			}
+
+			if diff := cmp.Diff(tt.want, got, cmpopts.IgnoreUnexported(proto.Block{})); diff != "" {
+				t.Errorf("Diagnostic values are mismatch (-want +got):%s\n", diff)
+			}
+		})
+	}
+}
+
+// TestDictionaryContains is the unittest for Dictionary.Contains.
+func TestDictionaryContains(t *testing.T) {
+	d := myAst.Dictionary{}
+	d.AddWords("handle", "value", "Commentcov", "make", "run", "company", "happy")
+
+	tests := []struct {
+		name string
+		word string
+		want bool
+	}{
+		{name: "exact", word: "value", want: true},
+		{name: "case insensitive", word: "Value", want: true},
+		{name: "added in uppercase", word: "commentcov", want: true},
+		{name: "plural", word: "values", want: true},
+		{name: "inflected", word: "handled", want: true},
+		{name: "possessive", word: "value's", want: true},
+		{name: "dropped e", word: "making", want: true},
+		{name: "doubled consonant", word: "running", want: true},
+		{name: "y to ies", word: "companies", want: true},
+		{name: "y to ily", word: "happily", want: true},
+		{name: "unknown", word: "valeu", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := d.Contains(tt.word)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("bool values are mismatch (-want +got):%s\n", diff)
+			}
+		})
+	}
+}
+
+// TestBuiltinDictionary is the unittest for BuiltinDictionary.
+func TestBuiltinDictionary(t *testing.T) {
+	d := myAst.BuiltinDictionary()
+
+	for _, w := range []string{"customer", "payment", "invoice", "billing", "beautiful", "goroutine", "unmarshal"} {
+		if !d.Contains(w) {
+			t.Errorf("BuiltinDictionary() does not contain %q", w)
+		}
+	}
+}
+
+// TestDictionaryLoadDictionary is the unittest for Dictionary.LoadDictionary.
+func TestDictionaryLoadDictionary(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dictionary.txt")
+	if err := os.WriteFile(path, []byte("# project words\ncommentcov\n\n  Hoge  \n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	got := myAst.Dictionary{}
+	if err := got.LoadDictionary(path); err != nil {
+		t.Fatal(err)
+	}
+
+	want := myAst.Dictionary{"commentcov": true, "hoge": true}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Dictionary values are mismatch (-want +got):%s\n", diff)
+	}
+
+	if err := got.LoadDictionary(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Errorf("error is expected for the missing file")
+	}
+}
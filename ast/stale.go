@@ -0,0 +1,101 @@
+package ast
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/commentcov/commentcov/proto"
+)
+
+// StaleCommentCheck is the name of the check for the comments left stale after renames.
+const StaleCommentCheck = "stale_comment"
+
+// articles are the words allowed to precede the identifier at the beginning of the doc comments.
+var articles = []string{"A", "An", "The"}
+
+// CheckStaleComment reports the header comments of the CoverageItem whose leading identifier-like word
+// names a declaration other than the one they are attached to, like `// NewClient creates` above `func NewHTTPClient`.
+// names are the identifiers the comments may start with, which are the ones declared together with the CoverageItem.
+// The package comments are expected to start with `Package <name>`.
+func CheckStaleComment(file string, fset *token.FileSet, f *ast.File, pkg *Package, ci *proto.CoverageItem, names []string) []*Diagnostic {
+	ds := []*Diagnostic{}
+	for _, cg := range HeaderCommentGroups(fset, f, ci.TargetBlock) {
+		words := strings.Fields(NormalizeGroup(cg))
+		if ci.Scope == proto.CoverageItem_FILE {
+			if len(words) < 2 || words[0] != "Package" {
+				continue
+			}
+			words = words[1:]
+		}
+
+		if len(words) > 1 && slices.Contains(articles, words[0]) {
+			words = words[1:]
+		}
+
+		if len(words) == 0 {
+			continue
+		}
+
+		word := strings.TrimRight(words[0], ".,:;")
+		if slices.Contains(names, word) || !token.IsIdentifier(word) {
+			continue
+		}
+
+		// the word following `Package` is always the package name.
+		if ci.Scope != proto.CoverageItem_FILE && !isIdentifierLike(word, pkg) {
+			continue
+		}
+
+		ds = append(ds, &Diagnostic{
+			Check:      StaleCommentCheck,
+			File:       file,
+			Identifier: ci.Identifier,
+			Block:      FindInCommentGroup(fset, cg, word),
+			Message:    fmt.Sprintf("comment starts with %s but is attached to %s", word, ci.Identifier),
+			Suggestion: suggestedName(ci.Identifier, names),
+		})
+	}
+
+	return ds
+}
+
+// suggestedName returns the entry of names the identifier refers to, which the comment should start with.
+// The identifiers of the methods and the fields are prefixed with their types, and may be qualified.
+func suggestedName(identifier string, names []string) string {
+	for _, n := range names {
+		if identifier == n || strings.HasSuffix(identifier, "."+n) {
+			return n
+		}
+	}
+
+	if len(names) > 0 {
+		return names[0]
+	}
+
+	return identifier
+}
+
+// isIdentifierLike returns true if the word is an identifier declared in the package,
+// or an identifier written in mixedCaps like NewClient which is unlikely to be an English word.
+// The words in all uppercase like TODO are not regarded as identifiers unless declared.
+func isIdentifierLike(word string, pkg *Package) bool {
+	if pkg.HasSymbol("", word) {
+		return true
+	}
+
+	if strings.ToUpper(word) == word {
+		return false
+	}
+
+	for i, r := range word {
+		if i > 0 && (unicode.IsUpper(r) || r == '_') {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,190 @@
+package ast_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/commentcov/commentcov/proto"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	myAst "github.com/commentcov/commentcov-plugin-go/ast"
+)
+
+// TestCheckStaleComment is the unittest for CheckStaleComment.
+//
+//nolint:funlen
+func TestCheckStaleComment(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []*myAst.Diagnostic
+	}{
+		{
+			name: "comment after rename",
+			src: `// Package hoge is hoge.
+package hoge
+
+// NewClient creates a client.
+func NewHTTPClient() {}
+`,
+			want: []*myAst.Diagnostic{
+				{
+					Check:      myAst.StaleCommentCheck,
+					File:       "hoge.go",
+					Identifier: "NewHTTPClient",
+					Block: &proto.Block{
+						StartLine:   4,
+						StartColumn: 4,
+						EndLine:     4,
+						EndColumn:   13,
+					},
+					Message:    "comment starts with NewClient but is attached to NewHTTPClient",
+					Suggestion: "NewHTTPClient",
+				},
+			},
+		},
+		{
+			name: "package comment after rename",
+			src: `// Package fuga is hoge.
+package hoge
+`,
+			want: []*myAst.Diagnostic{
+				{
+					Check:      myAst.StaleCommentCheck,
+					File:       "hoge.go",
+					Identifier: "hoge",
+					Block: &proto.Block{
+						StartLine:   1,
+						StartColumn: 12,
+						EndLine:     1,
+						EndColumn:   16,
+					},
+					Message:    "comment starts with fuga but is attached to hoge",
+					Suggestion: "hoge",
+				},
+			},
+		},
+		{
+			name: "comment naming another declaration in lowercase",
+			src: `package hoge
+
+// helper does something.
+func helperV2() {}
+
+func helper() {}
+`,
+			want: []*myAst.Diagnostic{
+				{
+					Check:      myAst.StaleCommentCheck,
+					File:       "hoge.go",
+					Identifier: "helperV2",
+					Block: &proto.Block{
+						StartLine:   3,
+						StartColumn: 4,
+						EndLine:     3,
+						EndColumn:   10,
+					},
+					Message:    "comment starts with helper but is attached to helperV2",
+					Suggestion: "helperV2",
+				},
+			},
+		},
+		{
+			name: "up-to-date comments",
+			src: `package hoge
+
+// MyFunc does something.
+func MyFunc() {}
+
+// A MyType is something.
+type MyType struct{}
+
+// MyVarB and MyVarA are something.
+var MyVarA, MyVarB string
+
+// Deprecated: Use MyFunc instead.
+func OldFunc() {}
+
+// TODO fix this.
+func Todo() {}
+
+// Returns something.
+func Something() {}
+`,
+			want: []*myAst.Diagnostic{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			f, err := parser.ParseFile(fset, "hoge.go", tt.src, parser.ParseComments)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pkg := myAst.NewPackage("hoge", f)
+
+			ci := myAst.ProcessPackageCoverage("hoge.go", fset, f)
+			got := myAst.CheckStaleComment("hoge.go", fset, f, pkg, ci, []string{f.Name.Name})
+			for _, decl := range f.Decls {
+				switch d := decl.(type) {
+				case *ast.FuncDecl:
+					ci := myAst.ProcessFunctionCoverage("hoge.go", fset, f, d)
+					got = append(got, myAst.CheckStaleComment("hoge.go", fset, f, pkg, ci, []string{d.Name.Name})...)
+
+				case *ast.GenDecl:
+					names := myAst.SpecNames(d)
+					for _, ci := range myAst.ProcessGenDeclCoverage("hoge.go", fset, f, d) {
+						got = append(got, myAst.CheckStaleComment("hoge.go", fset, f, pkg, ci, names[ci.Identifier])...)
+					}
+				}
+			}
+
+			if diff := cmp.Diff(tt.want, got, cmpopts.IgnoreUnexported(proto.Block{})); diff != "" {
+				t.Errorf("Diagnostic values are mismatch (-want +got):%s\n", diff)
+			}
+		})
+	}
+}
+
+// TestAnalyzeFile_StaleField is the unittest for the stale comments of the fields reported by Analyzer.AnalyzeFile.
+func TestAnalyzeFile_StaleField(t *testing.T) {
+	src := `// Package hoge is a package.
+package hoge
+
+// Config is the configuration.
+type Config struct {
+	// MaxSize is the maximum size.
+	MaxBytes int
+}
+`
+	file := filepath.Join(t.TempDir(), "hoge.go")
+	if err := os.WriteFile(file, []byte(src), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	options := myAst.DefaultOptions()
+	options.Kinds = map[string]bool{myAst.KindField: true}
+
+	res, err := myAst.NewAnalyzer(options).AnalyzeFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := []string{}
+	for _, d := range res.Diagnostics {
+		if d.Check == myAst.StaleCommentCheck {
+			got = append(got, d.Identifier+" -> "+d.Suggestion)
+		}
+	}
+
+	want := []string{"Config.MaxBytes -> MaxBytes"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Diagnostic values are mismatch (-want +got):%s\n", diff)
+	}
+}
@@ -0,0 +1,93 @@
+package ast
+
+import (
+	"go/ast"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// The modes of measuring the test files.
+const (
+	// TestModeMeasure measures the test files as the production code.
+	TestModeMeasure = "measure"
+	// TestModeExempt exempts the test entry points from the coverage, measuring the test helpers.
+	TestModeExempt = "exempt"
+	// TestModeSeparate excludes all the items of the test files from the coverage, scoring them per Category separately.
+	TestModeSeparate = "separate"
+)
+
+// The categories of the CoverageItems.
+const (
+	// CategoryProduction is the category of the items of the non-test files.
+	CategoryProduction = "production"
+	// CategoryTest is the category of the test entry points, Test*, Benchmark*, Fuzz*, Example* and TestMain,
+	// and the package clauses of the test files.
+	CategoryTest = "test"
+	// CategoryTestHelper is the category of the other declarations in the test files.
+	CategoryTestHelper = "test_helper"
+)
+
+// testFileSuffix is the suffix of the test files.
+const testFileSuffix = "_test.go"
+
+// externalTestSuffix is the suffix of the names of the external test packages.
+const externalTestSuffix = "_test"
+
+// testFuncPrefixes are the prefixes of the functions run by go test, other than the examples.
+var testFuncPrefixes = []string{"Test", "Benchmark", "Fuzz"}
+
+// IsTestFile returns true if the given file is a test file.
+func IsTestFile(file string) bool {
+	return strings.HasSuffix(file, testFileSuffix)
+}
+
+// IsExternalTestPackage returns true if the given package name is of an external test package, like `hoge_test`.
+func IsExternalTestPackage(name string) bool {
+	return strings.HasSuffix(name, externalTestSuffix) && name != externalTestSuffix
+}
+
+// IsTestFunc returns true if the given function is a test entry point run by go test:
+// TestXxx, BenchmarkXxx, FuzzXxx, ExampleXxx and TestMain.
+// Xxx must not start with a lowercase letter, as go test requires.
+func IsTestFunc(fdecl *ast.FuncDecl) bool {
+	if fdecl.Recv != nil {
+		return false
+	}
+
+	if IsExampleFunc(fdecl) {
+		return true
+	}
+
+	name := fdecl.Name.Name
+	for _, prefix := range testFuncPrefixes {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		rest := strings.TrimPrefix(name, prefix)
+		if r, _ := utf8.DecodeRuneInString(rest); rest == "" || !unicode.IsLower(r) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CategoryOf returns the category of the declaration in the given file.
+// decl is nil for the package clause.
+func CategoryOf(file string, decl ast.Decl) string {
+	if !IsTestFile(file) {
+		return CategoryProduction
+	}
+
+	if decl == nil {
+		return CategoryTest
+	}
+
+	if fdecl, ok := decl.(*ast.FuncDecl); ok && IsTestFunc(fdecl) {
+		return CategoryTest
+	}
+
+	return CategoryTestHelper
+}
@@ -0,0 +1,170 @@
+package ast_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	myAst "github.com/commentcov/commentcov-plugin-go/ast"
+)
+
+// TestIsTestFunc is the unittest for IsTestFunc.
+func TestIsTestFunc(t *testing.T) {
+	src := `package hoge_test
+
+func TestFoo(t *testing.T) {}
+
+func Test(t *testing.T) {}
+
+func TestMain(m *testing.M) {}
+
+func Testify() {}
+
+func BenchmarkFoo(b *testing.B) {}
+
+func FuzzFoo(f *testing.F) {}
+
+func ExampleFoo() {}
+
+func newFixture() {}
+
+func (s *suite) TestBar() {}
+`
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "hoge_test.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]bool{
+		"TestFoo":      true,
+		"Test":         true,
+		"TestMain":     true,
+		"Testify":      false,
+		"BenchmarkFoo": true,
+		"FuzzFoo":      true,
+		"ExampleFoo":   true,
+		"newFixture":   false,
+		"TestBar":      false,
+	}
+
+	got := map[string]bool{}
+	for _, decl := range f.Decls {
+		fdecl := decl.(*ast.FuncDecl)
+		got[fdecl.Name.Name] = myAst.IsTestFunc(fdecl)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("IsTestFunc values are mismatch (-want +got):%s\n", diff)
+	}
+}
+
+// TestAnalyzeFile_TestMode is the unittest for Analyzer.AnalyzeFile with Options.TestMode.
+//
+//nolint:funlen
+func TestAnalyzeFile_TestMode(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"hoge.go": "package hoge\n\n// Foo does.\nfunc Foo() {}\n",
+		"hoge_test.go": `package hoge_test
+
+func TestFoo(t *testing.T) {}
+
+// fixture is the test fixture.
+type fixture struct{}
+`,
+		"helper_test.go": "package hoge_test\n\nfunc newFixture() *fixture { return nil }\n",
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name          string
+		mode          string
+		wantItems     map[string]string
+		wantIgnored   []string
+		wantSeparated []string
+	}{
+		{
+			name: "measure",
+			mode: myAst.TestModeMeasure,
+			wantItems: map[string]string{
+				"hoge_test": myAst.CategoryTest,
+				"TestFoo":   myAst.CategoryTest,
+				"fixture":   myAst.CategoryTestHelper,
+			},
+			wantIgnored:   []string{},
+			wantSeparated: []string{},
+		},
+		{
+			name: "exempt",
+			mode: myAst.TestModeExempt,
+			wantItems: map[string]string{
+				"fixture": myAst.CategoryTestHelper,
+			},
+			wantIgnored:   []string{"hoge_test", "TestFoo"},
+			wantSeparated: []string{},
+		},
+		{
+			name:          "separate",
+			mode:          myAst.TestModeSeparate,
+			wantItems:     map[string]string{},
+			wantIgnored:   []string{},
+			wantSeparated: []string{"hoge_test", "TestFoo", "fixture"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := myAst.DefaultOptions()
+			options.TestMode = tt.mode
+
+			res, err := myAst.NewAnalyzer(options).AnalyzeFile(filepath.Join(dir, "hoge_test.go"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !res.Package.IsExternalTest() {
+				t.Errorf("want external test package, got %s\n", res.Package.Name)
+			}
+
+			// the declarations in the other test files of the external test package are loaded.
+			if !res.Package.HasSymbol("", "newFixture") {
+				t.Errorf("want newFixture declared in %s\n", res.Package.Name)
+			}
+
+			gotItems := map[string]string{}
+			for _, ci := range res.Items {
+				gotItems[ci.Identifier] = res.Details[ci].Category
+			}
+			if diff := cmp.Diff(tt.wantItems, gotItems); diff != "" {
+				t.Errorf("CoverageItem categories are mismatch (-want +got):%s\n", diff)
+			}
+
+			gotIgnored := []string{}
+			for _, ig := range res.Ignored {
+				gotIgnored = append(gotIgnored, ig.Item.Identifier)
+			}
+			if diff := cmp.Diff(tt.wantIgnored, gotIgnored); diff != "" {
+				t.Errorf("Ignored values are mismatch (-want +got):%s\n", diff)
+			}
+
+			gotSeparated := []string{}
+			for _, ci := range res.Separated {
+				gotSeparated = append(gotSeparated, ci.Identifier)
+			}
+			if diff := cmp.Diff(tt.wantSeparated, gotSeparated); diff != "" {
+				t.Errorf("Separated values are mismatch (-want +got):%s\n", diff)
+			}
+		})
+	}
+}
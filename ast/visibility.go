@@ -0,0 +1,101 @@
+package ast
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/commentcov/commentcov/proto"
+)
+
+// internalDir is the name of the directories whose packages can be imported only from the tree rooted at their parents.
+const internalDir = "internal"
+
+// IsInternal returns true if the package of the given directory is an internal package,
+// which is the directory named internal or under it.
+// The directories above the module root are not taken into account.
+func IsInternal(dir string) bool {
+	if dir == "" {
+		return false
+	}
+
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return false
+	}
+
+	if root := ModuleRoot(abs); root != "" {
+		rel, err := filepath.Rel(root, abs)
+		if err != nil {
+			return false
+		}
+		abs = rel
+	}
+
+	return slices.Contains(strings.Split(filepath.ToSlash(abs), "/"), internalDir)
+}
+
+// PrivateScope returns the private counterpart of the given scope.
+// The package comment is mapped to CoverageItem_PRIVATE_MODULE.
+//
+//nolint:exhaustive
+func PrivateScope(scope proto.CoverageItem_Scope) proto.CoverageItem_Scope {
+	switch scope {
+	case proto.CoverageItem_FILE, proto.CoverageItem_PUBLIC_MODULE:
+		return proto.CoverageItem_PRIVATE_MODULE
+	case proto.CoverageItem_PUBLIC_CLASS:
+		return proto.CoverageItem_PRIVATE_CLASS
+	case proto.CoverageItem_PUBLIC_TYPE:
+		return proto.CoverageItem_PRIVATE_TYPE
+	case proto.CoverageItem_PUBLIC_FUNCTION:
+		return proto.CoverageItem_PRIVATE_FUNCTION
+	case proto.CoverageItem_PUBLIC_VARIABLE:
+		return proto.CoverageItem_PRIVATE_VARIABLE
+	}
+
+	return scope
+}
+
+// IsPrivateScope returns true if the given scope is a private one.
+//
+//nolint:exhaustive
+func IsPrivateScope(scope proto.CoverageItem_Scope) bool {
+	switch scope {
+	case proto.CoverageItem_PRIVATE_MODULE,
+		proto.CoverageItem_PRIVATE_CLASS,
+		proto.CoverageItem_PRIVATE_TYPE,
+		proto.CoverageItem_PRIVATE_FUNCTION,
+		proto.CoverageItem_PRIVATE_VARIABLE:
+		return true
+	}
+
+	return false
+}
+
+// PublicScope returns the public counterpart of the given scope.
+//
+//nolint:exhaustive
+func PublicScope(scope proto.CoverageItem_Scope) proto.CoverageItem_Scope {
+	switch scope {
+	case proto.CoverageItem_PRIVATE_CLASS:
+		return proto.CoverageItem_PUBLIC_CLASS
+	case proto.CoverageItem_PRIVATE_TYPE:
+		return proto.CoverageItem_PUBLIC_TYPE
+	case proto.CoverageItem_PRIVATE_FUNCTION:
+		return proto.CoverageItem_PUBLIC_FUNCTION
+	case proto.CoverageItem_PRIVATE_VARIABLE:
+		return proto.CoverageItem_PUBLIC_VARIABLE
+	}
+
+	return scope
+}
+
+// CommandScope returns the scope in a main package, which has no importable API.
+// The package comment stays CoverageItem_FILE as the usage documentation of the command, and the others are private.
+func CommandScope(scope proto.CoverageItem_Scope) proto.CoverageItem_Scope {
+	if scope == proto.CoverageItem_FILE {
+		return scope
+	}
+
+	return PrivateScope(scope)
+}
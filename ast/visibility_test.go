@@ -0,0 +1,223 @@
+package ast_test
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/commentcov/commentcov/proto"
+	"github.com/google/go-cmp/cmp"
+
+	myAst "github.com/commentcov/commentcov-plugin-go/ast"
+)
+
+// TestIsInternal is the unittest for IsInternal.
+func TestIsInternal(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "internal", "repo")
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/repo\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		dir  string
+		want bool
+	}{
+		{
+			name: "internal package",
+			dir:  filepath.Join(root, "internal"),
+			want: true,
+		},
+		{
+			name: "under internal package",
+			dir:  filepath.Join(root, "pkg", "internal", "cache"),
+			want: true,
+		},
+		{
+			name: "internal above module root",
+			dir:  filepath.Join(root, "pkg"),
+			want: false,
+		},
+		{
+			name: "internal in name",
+			dir:  filepath.Join(root, "internalize"),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := myAst.IsInternal(tt.dir)
+			if tt.want != got {
+				t.Errorf("want %v, got %v\n", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestAnalyzeFile_Internal is the unittest for Analyzer.AnalyzeFile of the internal packages.
+func TestAnalyzeFile_Internal(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "internal", "hoge")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/repo\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	src := `// Package hoge is internal.
+package hoge
+
+// Foo is exported.
+type Foo struct{}
+
+// ID is exported.
+type ID int
+
+// Bar is exported.
+func Bar() {}
+
+func baz() {}
+
+// Qux is exported.
+var Qux int
+`
+	file := filepath.Join(dir, "hoge.go")
+	if err := os.WriteFile(file, []byte(src), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name            string
+		internalPrivate bool
+		want            map[string]proto.CoverageItem_Scope
+	}{
+		{
+			name:            "internal private",
+			internalPrivate: true,
+			want: map[string]proto.CoverageItem_Scope{
+				"hoge": proto.CoverageItem_PRIVATE_MODULE,
+				"Foo":  proto.CoverageItem_PRIVATE_CLASS,
+				"ID":   proto.CoverageItem_PRIVATE_TYPE,
+				"Bar":  proto.CoverageItem_PRIVATE_FUNCTION,
+				"baz":  proto.CoverageItem_PRIVATE_FUNCTION,
+				"Qux":  proto.CoverageItem_PRIVATE_VARIABLE,
+			},
+		},
+		{
+			name:            "capitalization only",
+			internalPrivate: false,
+			want: map[string]proto.CoverageItem_Scope{
+				"hoge": proto.CoverageItem_FILE,
+				"Foo":  proto.CoverageItem_PUBLIC_CLASS,
+				"ID":   proto.CoverageItem_PUBLIC_TYPE,
+				"Bar":  proto.CoverageItem_PUBLIC_FUNCTION,
+				"baz":  proto.CoverageItem_PRIVATE_FUNCTION,
+				"Qux":  proto.CoverageItem_PUBLIC_VARIABLE,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := myAst.DefaultOptions()
+			options.InternalPrivate = tt.internalPrivate
+
+			res, err := myAst.NewAnalyzer(options).AnalyzeFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := map[string]proto.CoverageItem_Scope{}
+			for _, ci := range res.Items {
+				got[ci.Identifier] = ci.Scope
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Scope values are mismatch (-want +got):%s\n", diff)
+			}
+		})
+	}
+}
+
+// TestAnalyze_Command is the unittest for Analyzer.Analyze of the main packages.
+func TestAnalyze_Command(t *testing.T) {
+	src := `// Hoge prints the greeting.
+//
+// Usage:
+//
+//	hoge [flags]
+package main
+
+// Config is the config of the command.
+type Config struct{}
+
+// Mode is the mode of the command.
+type Mode string
+
+// Run runs the command.
+func Run() {}
+
+func main() {}
+`
+
+	tests := []struct {
+		name           string
+		commandPrivate bool
+		want           map[string]proto.CoverageItem_Scope
+	}{
+		{
+			name:           "command private",
+			commandPrivate: true,
+			want: map[string]proto.CoverageItem_Scope{
+				"main":   proto.CoverageItem_FILE,
+				"Config": proto.CoverageItem_PRIVATE_CLASS,
+				"Mode":   proto.CoverageItem_PRIVATE_TYPE,
+				"Run":    proto.CoverageItem_PRIVATE_FUNCTION,
+			},
+		},
+		{
+			name:           "capitalization only",
+			commandPrivate: false,
+			want: map[string]proto.CoverageItem_Scope{
+				"main":   proto.CoverageItem_FILE,
+				"Config": proto.CoverageItem_PUBLIC_CLASS,
+				"Mode":   proto.CoverageItem_PUBLIC_TYPE,
+				"Run":    proto.CoverageItem_PUBLIC_FUNCTION,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			f, err := parser.ParseFile(fset, "main.go", src, parser.ParseComments)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			options := myAst.DefaultOptions()
+			options.CommandPrivate = tt.commandPrivate
+			res := myAst.NewAnalyzer(options).Analyze("main.go", fset, f, myAst.NewPackage("main", f))
+
+			// both the package comment and func main are identified as main.
+			got := map[string]proto.CoverageItem_Scope{}
+			for _, ci := range res.Items {
+				if ci.Scope == proto.CoverageItem_PRIVATE_FUNCTION && ci.Identifier == "main" {
+					continue
+				}
+				got[ci.Identifier] = ci.Scope
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Scope values are mismatch (-want +got):%s\n", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package ast
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"golang.org/x/mod/modfile"
+)
+
+// goWorkFile is the name of the workspace definition file.
+const goWorkFile = "go.work"
+
+// goWorkEnv is the name of the environment variable overriding the workspace file, as the go command does.
+// The workspace mode is disabled if it is "off".
+const goWorkEnv = "GOWORK"
+
+// Workspace is the Go workspace tying the modules together.
+type Workspace struct {
+	// File is the path to go.work.
+	File string
+	// Modules are the root directories of the modules in the `use` directives, as the absolute paths.
+	Modules []string
+}
+
+// WorkspaceFile returns the path to the go.work for the given directory.
+// It is the path in the environment variable GOWORK if set, otherwise go.work found first walking up from the directory.
+// It returns "" if there is no go.work or the workspace mode is disabled.
+func WorkspaceFile(dir string) string {
+	switch env := os.Getenv(goWorkEnv); env {
+	case "":
+	case "off":
+		return ""
+	default:
+		return env
+	}
+
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+
+	for d := abs; ; d = filepath.Dir(d) {
+		if fi, err := os.Stat(filepath.Join(d, goWorkFile)); err == nil && !fi.IsDir() {
+			return filepath.Join(d, goWorkFile)
+		}
+
+		if parent := filepath.Dir(d); parent == d {
+			return ""
+		}
+	}
+}
+
+// LoadWorkspace reads the given go.work.
+func LoadWorkspace(file string) (*Workspace, error) {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := os.ReadFile(abs)
+	if err != nil {
+		return nil, err
+	}
+
+	wf, err := modfile.ParseWork(abs, b, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", abs, err)
+	}
+
+	w := &Workspace{
+		File: abs,
+	}
+	for _, u := range wf.Use {
+		dir := filepath.FromSlash(u.Path)
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(filepath.Dir(abs), dir)
+		}
+		w.Modules = append(w.Modules, filepath.Clean(dir))
+	}
+
+	return w, nil
+}
+
+// Uses returns true if the module of the given root directory is in the `use` directives of the Workspace.
+func (w *Workspace) Uses(root string) bool {
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return false
+	}
+
+	return slices.Contains(w.Modules, abs)
+}
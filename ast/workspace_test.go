@@ -0,0 +1,154 @@
+package ast_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	myAst "github.com/commentcov/commentcov-plugin-go/ast"
+)
+
+// writeModule writes go.mod of the module path and a Go file of the package in the directory.
+func writeModule(t *testing.T, dir, modulePath string) string {
+	t.Helper()
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if modulePath != "" {
+		if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module "+modulePath+"\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	file := filepath.Join(dir, "hoge.go")
+	if err := os.WriteFile(file, []byte("// Package hoge is a package.\npackage hoge\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return file
+}
+
+// TestAnalyzeFile_Workspace is the unittest for Analyzer.AnalyzeFile in the workspaces.
+//
+//nolint:funlen
+func TestAnalyzeFile_Workspace(t *testing.T) {
+	t.Setenv("GOWORK", "")
+
+	root := t.TempDir()
+	work := "go 1.24\n\nuse (\n\t./a\n\t./b/sub\n)\n"
+	if err := os.WriteFile(filepath.Join(root, "go.work"), []byte(work), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"used":            writeModule(t, filepath.Join(root, "a"), "example.com/a"),
+		"package of used": writeModule(t, filepath.Join(root, "a", "pkg"), ""),
+		"nested not used": writeModule(t, filepath.Join(root, "a", "nested"), "example.com/a/nested"),
+		"not used":        writeModule(t, filepath.Join(root, "b"), "example.com/b"),
+		"nested used":     writeModule(t, filepath.Join(root, "b", "sub"), "example.com/b/sub"),
+		"no module":       writeModule(t, filepath.Join(root, "c"), ""),
+	}
+
+	tests := []struct {
+		name      string
+		workspace bool
+		want      map[string]string
+	}{
+		{
+			name:      "workspace",
+			workspace: true,
+			want: map[string]string{
+				"used":            "example.com/a",
+				"package of used": "example.com/a",
+				"nested used":     "example.com/b/sub",
+				"no module":       "",
+			},
+		},
+		{
+			name:      "module only",
+			workspace: false,
+			want: map[string]string{
+				"used":            "example.com/a",
+				"package of used": "example.com/a",
+				"nested not used": "example.com/a/nested",
+				"not used":        "example.com/b",
+				"nested used":     "example.com/b/sub",
+				"no module":       "",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := myAst.DefaultOptions()
+			options.Workspace = tt.workspace
+			analyzer := myAst.NewAnalyzer(options)
+
+			got := map[string]string{}
+			for name, file := range files {
+				res, err := analyzer.AnalyzeFile(file)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if res == nil {
+					continue
+				}
+
+				got[name] = ""
+				if res.Package.Module != nil {
+					got[name] = res.Package.Module.Path
+				}
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Module values are mismatch (-want +got):%s\n", diff)
+			}
+		})
+	}
+}
+
+// TestWorkspaceFile is the unittest for WorkspaceFile.
+func TestWorkspaceFile(t *testing.T) {
+	root := t.TempDir()
+	file := filepath.Join(root, "go.work")
+	if err := os.WriteFile(file, []byte("go 1.24\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Join(root, "a", "b")
+
+	tests := []struct {
+		name string
+		env  string
+		want string
+	}{
+		{
+			name: "walking up",
+			want: file,
+		},
+		{
+			name: "GOWORK",
+			env:  "/path/to/go.work",
+			want: "/path/to/go.work",
+		},
+		{
+			name: "GOWORK off",
+			env:  "off",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GOWORK", tt.env)
+
+			got := myAst.WorkspaceFile(dir)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("WorkspaceFile() values are mismatch (-want +got):%s\n", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,535 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/mattn/go-zglob"
+	"gopkg.in/yaml.v3"
+
+	"github.com/commentcov/commentcov-plugin-go/ast"
+)
+
+// FileName is the name of the config file looked up from the directories of the target files.
+const FileName = ".commentcov-go.yaml"
+
+// EnvName is the name of the environment variable holding the path to the config file.
+// If set, the config file is not looked up.
+const EnvName = "COMMENTCOV_GO_CONFIG"
+
+// optInChecks are the checks disabled unless enabled in the config.
+var optInChecks = []string{
+	ast.SpellCheck,
+	ast.LanguageCheck,
+}
+
+// languages are the labels accepted as the language of the header comments.
+var languages = []string{
+	ast.LanguageEnglish,
+	ast.LanguageJapanese,
+	ast.LanguageChinese,
+	ast.LanguageKorean,
+	ast.LanguageLatin,
+	ast.LanguageCyrillic,
+	ast.LanguageGreek,
+	ast.LanguageArabic,
+	ast.LanguageHebrew,
+	ast.LanguageThai,
+	ast.LanguageDevanagari,
+}
+
+// Config is the plugin config.
+type Config struct {
+	// Path is the path to the config file. It is empty for the default config.
+	Path string `yaml:"-"`
+
+	// Attribution is how the comments are attributed to the declarations, either "spec" or "group".
+	Attribution string `yaml:"attribution"`
+	// Exclude is the exclusion policies of the files.
+	Exclude Exclude `yaml:"exclude"`
+	// Examples enables scanning the test files for the example functions.
+	Examples bool `yaml:"examples"`
+	// Params is the settings of the parameter documentation coverage.
+	Params Params `yaml:"params"`
+	// Checks enables or disables the checks by name.
+	Checks map[string]bool `yaml:"checks"`
+	// Spell is the settings of the spell check.
+	Spell Spell `yaml:"spell"`
+	// Language is the label of the language the header comments are required to be written in.
+	Language string `yaml:"language"`
+	// Thresholds are the thresholds of the checks.
+	Thresholds Thresholds `yaml:"thresholds"`
+	// Visibility is the visibility policies deciding the public and the private scopes.
+	Visibility Visibility `yaml:"visibility"`
+	// Tests is the settings of the test files.
+	Tests Tests `yaml:"tests"`
+	// Identifiers is the settings of the identifiers of the coverage items.
+	Identifiers Identifiers `yaml:"identifiers"`
+	// Modules is the settings of the module boundaries.
+	Modules Modules `yaml:"modules"`
+	// ThirdParty is the settings of the third-party code.
+	ThirdParty ThirdParty `yaml:"third_party"`
+	// Rules are the policy rules overriding the settings for the files matching them. The first matching rule is applied.
+	Rules []Rule `yaml:"rules"`
+}
+
+// Exclude is the exclusion policies of the files.
+type Exclude struct {
+	// Paths are the glob patterns of the files excluded, relative to the directory of the config file.
+	Paths []string `yaml:"paths"`
+	// Generated excludes the generated files.
+	Generated bool `yaml:"generated"`
+}
+
+// Params is the settings of the parameter documentation coverage.
+type Params struct {
+	// IgnoreTypes are the parameter types excluded.
+	IgnoreTypes []string `yaml:"ignore_types"`
+}
+
+// Spell is the settings of the spell check.
+type Spell struct {
+	// Dictionary is the path to the project dictionary file, relative to the directory of the config file.
+	Dictionary string `yaml:"dictionary"`
+}
+
+// Thresholds are the thresholds of the checks.
+type Thresholds struct {
+	// MinCommentWords is the minimum number of the words of the header comments.
+	MinCommentWords int `yaml:"min_comment_words"`
+	// MinParamRatio is the minimum parameter documentation coverage of the functions.
+	MinParamRatio float64 `yaml:"min_param_ratio"`
+}
+
+// Visibility is the visibility policies deciding the public and the private scopes.
+type Visibility struct {
+	// Internal maps the exported identifiers and the package comments of the internal packages to the private scopes.
+	Internal bool `yaml:"internal"`
+	// Main maps the identifiers of the main packages to the private scopes.
+	Main bool `yaml:"main"`
+	// Reachability defines the public scopes by the reachability from the exported API of the packages.
+	Reachability bool `yaml:"reachability"`
+}
+
+// Tests is the settings of the test files.
+type Tests struct {
+	// Mode is how the test files are measured, either "measure", "exempt" or "separate".
+	Mode string `yaml:"mode"`
+}
+
+// Identifiers is the settings of the identifiers of the coverage items.
+type Identifiers struct {
+	// Qualified qualifies the identifiers with the import paths resolved from the nearest go.mod.
+	Qualified bool `yaml:"qualified"`
+}
+
+// Modules is the settings of the module boundaries.
+type Modules struct {
+	// Workspace honors the `use` directives of go.work, excluding the files of the modules not in the workspace.
+	Workspace bool `yaml:"workspace"`
+}
+
+// ThirdParty is the settings of the third-party code.
+type ThirdParty struct {
+	// Roots are the directories of the third-party code, relative to the directory of the config file.
+	// The vendor directories and the module cache are recognized without them.
+	Roots []string `yaml:"roots"`
+	// Mode is how the third-party code is handled, either "skip" or "label".
+	Mode string `yaml:"mode"`
+}
+
+// Rule is a policy rule overriding the settings for the files matching it.
+type Rule struct {
+	// Name is the name of the rule recorded on the items. It defaults to `rules[<index>]`.
+	Name string `yaml:"name"`
+	// Paths are the glob patterns of the files the rule applies to, relative to the directory of the config file.
+	Paths []string `yaml:"paths"`
+	// Kinds enables or disables the kinds of the items by name, like "field", "method" and "private".
+	Kinds map[string]bool `yaml:"kinds"`
+	// Checks enables or disables the checks by name over Config.Checks.
+	Checks map[string]bool `yaml:"checks"`
+	// MinCommentWords overrides Thresholds.MinCommentWords if set. 0 disables the check.
+	MinCommentWords *int `yaml:"min_comment_words"`
+}
+
+// testModes are the modes accepted as Tests.Mode.
+var testModes = []string{
+	ast.TestModeMeasure,
+	ast.TestModeExempt,
+	ast.TestModeSeparate,
+}
+
+// Default returns the Config used when no config file is found.
+func Default() *Config {
+	opts := ast.DefaultOptions()
+
+	return &Config{
+		Attribution: opts.Attribution,
+		Params: Params{
+			IgnoreTypes: opts.IgnoreParamTypes,
+		},
+		Checks:   map[string]bool{},
+		Language: ast.LanguageEnglish,
+		Visibility: Visibility{
+			Internal:     opts.InternalPrivate,
+			Main:         opts.CommandPrivate,
+			Reachability: opts.Reachability,
+		},
+		Tests: Tests{
+			Mode: opts.TestMode,
+		},
+		Modules: Modules{
+			Workspace: opts.Workspace,
+		},
+		ThirdParty: ThirdParty{
+			Mode: opts.ThirdPartyMode,
+		},
+	}
+}
+
+// Load reads the config file of the given path over the Default.
+// It returns an error if the file has unknown fields or invalid values.
+func Load(configPath string) (*Config, error) {
+	b, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	c := Default()
+	c.Path = configPath
+
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	dec.KnownFields(true)
+	if err := dec.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid config %s: %w", configPath, err)
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", configPath, err)
+	}
+
+	for i := range c.Rules {
+		if c.Rules[i].Name == "" {
+			c.Rules[i].Name = fmt.Sprintf("rules[%d]", i)
+		}
+	}
+
+	return c, nil
+}
+
+// Validate returns all the errors of the values of the Config joined.
+func (c *Config) Validate() error {
+	errs := []error{}
+
+	if c.Attribution != ast.AttributionSpec && c.Attribution != ast.AttributionGroup {
+		errs = append(errs, fmt.Errorf(
+			"attribution: must be %q or %q, got %q", ast.AttributionSpec, ast.AttributionGroup, c.Attribution,
+		))
+	}
+
+	for _, p := range c.Exclude.Paths {
+		// zglob accepts the malformed patterns silently.
+		if _, err := path.Match(p, ""); err != nil {
+			errs = append(errs, fmt.Errorf("exclude.paths: invalid pattern %q: %w", p, err))
+		}
+	}
+
+	for name := range c.Checks {
+		if !slices.Contains(ast.Checks, name) {
+			errs = append(errs, fmt.Errorf("checks: unknown check %q, must be one of %s", name, strings.Join(ast.Checks, ", ")))
+		}
+	}
+
+	if !slices.Contains(languages, c.Language) {
+		errs = append(errs, fmt.Errorf("language: unknown language %q, must be one of %s", c.Language, strings.Join(languages, ", ")))
+	}
+
+	if !slices.Contains(testModes, c.Tests.Mode) {
+		errs = append(errs, fmt.Errorf("tests.mode: unknown mode %q, must be one of %s", c.Tests.Mode, strings.Join(testModes, ", ")))
+	}
+
+	if c.ThirdParty.Mode != ast.ThirdPartySkip && c.ThirdParty.Mode != ast.ThirdPartyLabel {
+		errs = append(errs, fmt.Errorf(
+			"third_party.mode: must be %q or %q, got %q", ast.ThirdPartySkip, ast.ThirdPartyLabel, c.ThirdParty.Mode,
+		))
+	}
+
+	for i, r := range c.Rules {
+		errs = append(errs, r.validate(fmt.Sprintf("rules[%d]", i))...)
+	}
+
+	if c.Thresholds.MinCommentWords < 0 {
+		errs = append(errs, fmt.Errorf("thresholds.min_comment_words: must not be negative, got %d", c.Thresholds.MinCommentWords))
+	}
+
+	if c.Thresholds.MinParamRatio < 0 || 1 < c.Thresholds.MinParamRatio {
+		errs = append(errs, fmt.Errorf("thresholds.min_param_ratio: must be between 0 and 1, got %v", c.Thresholds.MinParamRatio))
+	}
+
+	return errors.Join(errs...)
+}
+
+// validate returns the errors of the values of the Rule, prefixed by the given field name.
+func (r *Rule) validate(field string) []error {
+	errs := []error{}
+
+	if len(r.Paths) == 0 {
+		errs = append(errs, fmt.Errorf("%s.paths: must not be empty", field))
+	}
+
+	for _, p := range r.Paths {
+		if _, err := path.Match(p, ""); err != nil {
+			errs = append(errs, fmt.Errorf("%s.paths: invalid pattern %q: %w", field, p, err))
+		}
+	}
+
+	for kind := range r.Kinds {
+		if !slices.Contains(ast.Kinds, kind) {
+			errs = append(errs, fmt.Errorf("%s.kinds: unknown kind %q, must be one of %s", field, kind, strings.Join(ast.Kinds, ", ")))
+		}
+	}
+
+	for name := range r.Checks {
+		if !slices.Contains(ast.Checks, name) {
+			errs = append(errs, fmt.Errorf("%s.checks: unknown check %q, must be one of %s", field, name, strings.Join(ast.Checks, ", ")))
+		}
+	}
+
+	if r.MinCommentWords != nil && *r.MinCommentWords < 0 {
+		errs = append(errs, fmt.Errorf("%s.min_comment_words: must not be negative, got %d", field, *r.MinCommentWords))
+	}
+
+	return errs
+}
+
+// IsEnabled returns true if the check of the given name is enabled.
+// The checks not listed in Checks are enabled except the opt-in ones.
+func (c *Config) IsEnabled(check string) bool {
+	if enabled, ok := c.Checks[check]; ok {
+		return enabled
+	}
+
+	return !slices.Contains(optInChecks, check)
+}
+
+// isEnabled returns true if the check of the given name is enabled for the files of the Rule.
+// The Rule may be nil.
+func (c *Config) isEnabled(check string, r *Rule) bool {
+	if r != nil {
+		if enabled, ok := r.Checks[check]; ok {
+			return enabled
+		}
+	}
+
+	return c.IsEnabled(check)
+}
+
+// Options returns the ast.Options configured, without any Rule applied.
+func (c *Config) Options() *ast.Options {
+	return c.OptionsOf(nil)
+}
+
+// OptionsOf returns the ast.Options configured with the Rule applied. The Rule may be nil.
+func (c *Config) OptionsOf(r *Rule) *ast.Options {
+	opts := ast.DefaultOptions()
+	opts.IgnoreParamTypes = c.Params.IgnoreTypes
+	opts.Examples = c.Examples
+	opts.SpellCheck = c.isEnabled(ast.SpellCheck, r)
+	opts.SpellDictionary = c.resolve(c.Spell.Dictionary)
+	opts.Attribution = c.Attribution
+	opts.ExcludeGenerated = c.Exclude.Generated
+	opts.MinCommentWords = c.Thresholds.MinCommentWords
+	opts.MinParamRatio = c.Thresholds.MinParamRatio
+	opts.InternalPrivate = c.Visibility.Internal
+	opts.CommandPrivate = c.Visibility.Main
+	opts.Reachability = c.Visibility.Reachability
+	opts.TestMode = c.Tests.Mode
+	opts.QualifiedIdentifiers = c.Identifiers.Qualified
+	opts.Workspace = c.Modules.Workspace
+	opts.ThirdPartyMode = c.ThirdParty.Mode
+	for _, root := range c.ThirdParty.Roots {
+		opts.ThirdPartyRoots = append(opts.ThirdPartyRoots, c.resolve(root))
+	}
+
+	if c.isEnabled(ast.LanguageCheck, r) {
+		opts.Language = c.Language
+	}
+
+	for _, check := range ast.Checks {
+		if !c.isEnabled(check, r) {
+			opts.DisabledChecks[check] = true
+		}
+	}
+
+	if r != nil {
+		opts.Rule = r.Name
+		for kind, enabled := range r.Kinds {
+			opts.Kinds[kind] = enabled
+		}
+
+		if r.MinCommentWords != nil {
+			opts.MinCommentWords = *r.MinCommentWords
+		}
+	}
+
+	return opts
+}
+
+// RuleOf returns the first Rule matching the given file. It returns nil if no Rule matches.
+func (c *Config) RuleOf(file string) *Rule {
+	for i := range c.Rules {
+		if c.match(c.Rules[i].Paths, file) {
+			return &c.Rules[i]
+		}
+	}
+
+	return nil
+}
+
+// IsExcluded returns true if the given file matches any of Exclude.Paths.
+func (c *Config) IsExcluded(file string) bool {
+	return c.match(c.Exclude.Paths, file)
+}
+
+// match returns true if the given file matches any of the glob patterns relative to the directory of the config file.
+// The patterns ending with /** match all the files under the directories.
+func (c *Config) match(patterns []string, file string) bool {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return false
+	}
+
+	rel := abs
+	if r, err := filepath.Rel(c.dir(), abs); err == nil {
+		rel = r
+	}
+
+	for _, p := range patterns {
+		name := filepath.ToSlash(rel)
+		if filepath.IsAbs(p) {
+			name = filepath.ToSlash(abs)
+		}
+
+		pattern := filepath.ToSlash(filepath.Clean(p))
+		// zglob matches the trailing ** only within a directory.
+		if strings.HasSuffix(pattern, "/**") {
+			pattern += "/*"
+		}
+
+		if ok, _ := zglob.Match(pattern, name); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// dir returns the directory which the relative paths in the Config are based on.
+func (c *Config) dir() string {
+	if c.Path == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return "."
+		}
+
+		return wd
+	}
+
+	abs, err := filepath.Abs(c.Path)
+	if err != nil {
+		return filepath.Dir(c.Path)
+	}
+
+	return filepath.Dir(abs)
+}
+
+// resolve returns the path relative to the directory of the config file as an absolute path.
+func (c *Config) resolve(rel string) string {
+	if rel == "" || filepath.IsAbs(rel) {
+		return rel
+	}
+
+	return filepath.Join(c.dir(), rel)
+}
+
+// Find returns the path to the config file for the given file.
+// It is the path in the environment variable EnvName if set,
+// otherwise FileName found first walking up from the directory of the file.
+// It returns "" if no config file is found.
+func Find(file string) (string, error) {
+	if configPath := os.Getenv(EnvName); configPath != "" {
+		if _, err := os.Stat(configPath); err != nil {
+			return "", fmt.Errorf("invalid %s: %w", EnvName, err)
+		}
+
+		return configPath, nil
+	}
+
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to filepath.Abs: %w", err)
+	}
+
+	for dir := filepath.Dir(abs); ; dir = filepath.Dir(dir) {
+		configPath := filepath.Join(dir, FileName)
+		if _, err := os.Stat(configPath); err == nil {
+			return configPath, nil
+		}
+
+		if parent := filepath.Dir(dir); parent == dir {
+			return "", nil
+		}
+	}
+}
+
+// Resolver finds and loads the configs of the files, caching them.
+type Resolver struct {
+	paths   map[string]string
+	configs map[string]*Config
+}
+
+// NewResolver returns a new Resolver.
+func NewResolver() *Resolver {
+	return &Resolver{
+		paths:   map[string]string{},
+		configs: map[string]*Config{},
+	}
+}
+
+// ConfigOf returns the Config for the given file. It is the Default if no config file is found.
+func (r *Resolver) ConfigOf(file string) (*Config, error) {
+	dir := filepath.Dir(file)
+	configPath, ok := r.paths[dir]
+	if !ok {
+		var err error
+		configPath, err = Find(file)
+		if err != nil {
+			return nil, err
+		}
+
+		r.paths[dir] = configPath
+	}
+
+	if c, ok := r.configs[configPath]; ok {
+		return c, nil
+	}
+
+	c := Default()
+	if configPath != "" {
+		var err error
+		c, err = Load(configPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	r.configs[configPath] = c
+	return c, nil
+}
@@ -0,0 +1,368 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/commentcov/commentcov-plugin-go/ast"
+	"github.com/commentcov/commentcov-plugin-go/config"
+)
+
+// writeFile writes the given content to the file of the given path, creating the parent directories.
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestLoad is the unittest for Load.
+//
+//nolint:funlen
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    *ast.Options
+		wantErr []string
+	}{
+		{
+			name:    "empty",
+			content: "",
+			want:    config.Default().Options(),
+		},
+		{
+			name: "configured",
+			content: `attribution: group
+exclude:
+  generated: true
+examples: true
+params:
+  ignore_types: []
+checks:
+  spell: true
+  language: true
+  doc_format: false
+spell:
+  dictionary: words.txt
+language: ja
+thresholds:
+  min_comment_words: 3
+  min_param_ratio: 0.5
+visibility:
+  internal: false
+  main: false
+  reachability: true
+tests:
+  mode: separate
+identifiers:
+  qualified: true
+modules:
+  workspace: false
+third_party:
+  roots: [third_party]
+  mode: label
+`,
+			want: &ast.Options{
+				IgnoreParamTypes: []string{},
+				Examples:         true,
+				SpellCheck:       true,
+				SpellDictionary:  "words.txt",
+				Language:         ast.LanguageJapanese,
+				Attribution:      ast.AttributionGroup,
+				ExcludeGenerated: true,
+				DisabledChecks: map[string]bool{
+					ast.DocFormatCheck: true,
+				},
+				MinCommentWords:      3,
+				MinParamRatio:        0.5,
+				Reachability:         true,
+				TestMode:             ast.TestModeSeparate,
+				QualifiedIdentifiers: true,
+				ThirdPartyRoots:      []string{"third_party"},
+				ThirdPartyMode:       ast.ThirdPartyLabel,
+				Kinds:                map[string]bool{},
+			},
+		},
+		{
+			name:    "unknown field",
+			content: "chekcs:\n  spell: true\n",
+			wantErr: []string{"line 1: field chekcs not found"},
+		},
+		{
+			name: "invalid values",
+			content: `attribution: loose
+exclude:
+  paths: ["["]
+checks:
+  speling: true
+language: english
+tests:
+  mode: skip
+third_party:
+  mode: keep
+rules:
+  - paths: []
+    kinds:
+      fields: true
+    checks:
+      speling: false
+    min_comment_words: -1
+thresholds:
+  min_comment_words: -1
+  min_param_ratio: 2
+`,
+			wantErr: []string{
+				`attribution: must be "spec" or "group", got "loose"`,
+				`exclude.paths: invalid pattern "["`,
+				`checks: unknown check "speling"`,
+				`language: unknown language "english"`,
+				`tests.mode: unknown mode "skip"`,
+				`third_party.mode: must be "skip" or "label", got "keep"`,
+				"rules[0].paths: must not be empty",
+				`rules[0].kinds: unknown kind "fields"`,
+				`rules[0].checks: unknown check "speling"`,
+				"rules[0].min_comment_words: must not be negative, got -1",
+				"thresholds.min_comment_words: must not be negative, got -1",
+				"thresholds.min_param_ratio: must be between 0 and 1, got 2",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, config.FileName)
+			writeFile(t, path, tt.content)
+
+			c, err := config.Load(path)
+			if len(tt.wantErr) > 0 {
+				if err == nil {
+					t.Fatal("want error, got nil")
+				}
+
+				for _, want := range tt.wantErr {
+					if !strings.Contains(err.Error(), want) {
+						t.Errorf("want error containing %q, got %q\n", want, err.Error())
+					}
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.want.SpellDictionary != "" {
+				tt.want.SpellDictionary = filepath.Join(dir, tt.want.SpellDictionary)
+			}
+			for i, root := range tt.want.ThirdPartyRoots {
+				tt.want.ThirdPartyRoots[i] = filepath.Join(dir, root)
+			}
+
+			if diff := cmp.Diff(tt.want, c.Options()); diff != "" {
+				t.Errorf("Options values are mismatch (-want +got):%s\n", diff)
+			}
+		})
+	}
+}
+
+// TestFind is the unittest for Find.
+func TestFind(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, config.FileName), "")
+	writeFile(t, filepath.Join(dir, "sub", "pkg", config.FileName), "")
+
+	tests := []struct {
+		name string
+		file string
+		env  string
+		want string
+	}{
+		{
+			name: "nearest",
+			file: filepath.Join(dir, "sub", "pkg", "a.go"),
+			want: filepath.Join(dir, "sub", "pkg", config.FileName),
+		},
+		{
+			name: "walking up",
+			file: filepath.Join(dir, "sub", "b.go"),
+			want: filepath.Join(dir, config.FileName),
+		},
+		{
+			name: "environment variable",
+			file: filepath.Join(dir, "sub", "pkg", "a.go"),
+			env:  filepath.Join(dir, config.FileName),
+			want: filepath.Join(dir, config.FileName),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(config.EnvName, tt.env)
+
+			got, err := config.Find(tt.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.want != got {
+				t.Errorf("want %s, got %s\n", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestConfig_IsExcluded is the unittest for Config.IsExcluded.
+func TestConfig_IsExcluded(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, config.FileName)
+	writeFile(t, path, "exclude:\n  paths:\n    - \"**/*.pb.go\"\n    - internal/legacy/**\n")
+
+	c, err := config.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		file string
+		want bool
+	}{
+		{
+			name: "generated protobuf",
+			file: filepath.Join(dir, "api", "v1", "service.pb.go"),
+			want: true,
+		},
+		{
+			name: "excluded directory",
+			file: filepath.Join(dir, "internal", "legacy", "old.go"),
+			want: true,
+		},
+		{
+			name: "under excluded directory",
+			file: filepath.Join(dir, "internal", "legacy", "v1", "old.go"),
+			want: true,
+		},
+		{
+			name: "not excluded",
+			file: filepath.Join(dir, "internal", "app", "app.go"),
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := c.IsExcluded(tt.file)
+			if tt.want != got {
+				t.Errorf("want %v, got %v\n", tt.want, got)
+			}
+		})
+	}
+}
+
+// TestConfig_OptionsOf is the unittest for Config.RuleOf and Config.OptionsOf.
+//
+//nolint:funlen
+func TestConfig_OptionsOf(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, config.FileName)
+	writeFile(t, path, `thresholds:
+  min_comment_words: 3
+rules:
+  - name: api
+    paths: [pkg/api/**]
+    kinds:
+      field: true
+    checks:
+      spell: true
+  - name: commands
+    paths: [cmd/**]
+    kinds:
+      function: false
+      method: false
+      type: false
+      variable: false
+    min_comment_words: 0
+  - paths: [cmd/legacy/**]
+    kinds:
+      package: false
+`)
+
+	c, err := config.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		file  string
+		want  string
+		apply func(*ast.Options)
+	}{
+		{
+			name: "first rule",
+			file: filepath.Join(dir, "pkg", "api", "v1", "service.go"),
+			want: "api",
+			apply: func(o *ast.Options) {
+				o.SpellCheck = true
+				delete(o.DisabledChecks, ast.SpellCheck)
+				o.Kinds[ast.KindField] = true
+			},
+		},
+		{
+			name: "first matching rule",
+			file: filepath.Join(dir, "cmd", "legacy", "main.go"),
+			want: "commands",
+			apply: func(o *ast.Options) {
+				o.MinCommentWords = 0
+				o.Kinds[ast.KindFunction] = false
+				o.Kinds[ast.KindMethod] = false
+				o.Kinds[ast.KindType] = false
+				o.Kinds[ast.KindVariable] = false
+			},
+		},
+		{
+			name:  "no rule",
+			file:  filepath.Join(dir, "internal", "app", "app.go"),
+			want:  "",
+			apply: func(*ast.Options) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := c.RuleOf(tt.file)
+			got := ""
+			if rule != nil {
+				got = rule.Name
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("RuleOf() values are mismatch (-want +got):%s\n", diff)
+			}
+
+			want := c.Options()
+			want.Rule = tt.want
+			tt.apply(want)
+
+			if diff := cmp.Diff(want, c.OptionsOf(rule)); diff != "" {
+				t.Errorf("OptionsOf() values are mismatch (-want +got):%s\n", diff)
+			}
+		})
+	}
+
+	if diff := cmp.Diff("rules[2]", c.Rules[2].Name); diff != "" {
+		t.Errorf("Name values are mismatch (-want +got):%s\n", diff)
+	}
+}
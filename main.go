@@ -7,27 +7,63 @@ import (
 	"github.com/hashicorp/go-plugin"
 
 	"github.com/commentcov/commentcov-plugin-go/ast"
+	"github.com/commentcov/commentcov-plugin-go/config"
 )
 
+// analyzerKey is the key of the Analyzers, which are created per config and per policy rule.
+type analyzerKey struct {
+	config *config.Config
+	rule   *config.Rule
+}
+
 // pluginImpl implements pluggable.Pluggable.
 type pluginImpl struct {
 	logger hclog.Logger
 }
 
 // MeasureCoverage is the implementation of pluggable.Pluggable.
+// The files are analyzed with the config found for each of them, and the errors of the configs are returned to the host.
 func (i *pluginImpl) MeasureCoverage(files []string) ([]*proto.CoverageItem, error) {
 	items := make([]*proto.CoverageItem, 0)
+	results := make([]*ast.Result, 0, len(files))
+	resolver := config.NewResolver()
+	analyzers := map[analyzerKey]*ast.Analyzer{}
 
 	for _, file := range files {
-		cis, err := ast.FileToCoverageItems(i.logger, file)
+		cfg, err := resolver.ConfigOf(file)
+		if err != nil {
+			i.logger.Error(err.Error())
+			return []*proto.CoverageItem{}, err
+		}
+
+		if cfg.IsExcluded(file) {
+			continue
+		}
+
+		key := analyzerKey{config: cfg, rule: cfg.RuleOf(file)}
+		analyzer, ok := analyzers[key]
+		if !ok {
+			analyzer = ast.NewAnalyzer(cfg.OptionsOf(key.rule))
+			analyzers[key] = analyzer
+		}
+
+		res, err := analyzer.AnalyzeFile(file)
 		if err != nil {
 			i.logger.Trace(err.Error())
 			return []*proto.CoverageItem{}, err
 		}
 
-		items = append(items, cis...)
+		// the file is excluded by the config.
+		if res == nil {
+			continue
+		}
+
+		results = append(results, res)
+		items = append(items, res.Items...)
 	}
 
+	i.report(results)
+
 	return items, nil
 }
 
@@ -0,0 +1,442 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/commentcov/commentcov/proto"
+
+	"github.com/commentcov/commentcov-plugin-go/ast"
+)
+
+// packageStats aggregates the analysis results of a package.
+type packageStats struct {
+	name        string
+	dir         string
+	module      string
+	exported    int
+	withExample int
+	documented  int
+	oneLiners   int
+	metrics     ast.Metrics
+	languages   map[string]int
+	// command is true if the package is a main package, and usage is true if it has the package comment.
+	command bool
+	usage   bool
+	// externalTest is true if the package is an external test package.
+	externalTest bool
+	// categories are the coverage per ast.Detail.Category.
+	categories map[string]*categoryStats
+}
+
+// categoryStats is the number of the documented items and all the items of a category.
+type categoryStats struct {
+	documented int
+	total      int
+}
+
+// moduleStats is the coverage of a module.
+type moduleStats struct {
+	path string
+	dir  string
+	categoryStats
+}
+
+// thirdPartyStats is the coverage of a package of the third-party code.
+type thirdPartyStats struct {
+	origin string
+	name   string
+	dir    string
+	categoryStats
+}
+
+// report emits the analysis results which proto.CoverageItem cannot carry to the host through the logger.
+func (i *pluginImpl) report(results []*ast.Result) {
+	stats := map[string]*packageStats{}
+	keys := []string{}
+	// the modules are keyed by the directories, since the rules matching the same module load it separately.
+	modules := map[string]*moduleStats{}
+	moduleKeys := []string{}
+	items := map[string][]*proto.CoverageItem{}
+	all := []*proto.CoverageItem{}
+	owners := map[*proto.CoverageItem]*packageStats{}
+	thirdParty := map[string]*thirdPartyStats{}
+	thirdPartyKeys := []string{}
+
+	for _, res := range results {
+		key := filepath.Join(res.Package.Dir, res.Package.Name)
+
+		// the third-party code is kept out of the statistics of the first-party code.
+		if res.Origin != ast.OriginFirstParty {
+			ts, ok := thirdParty[key]
+			if !ok {
+				ts = &thirdPartyStats{origin: res.Origin, name: res.Package.Name, dir: res.Package.Dir}
+				thirdParty[key] = ts
+				thirdPartyKeys = append(thirdPartyKeys, key)
+			}
+
+			for _, ci := range res.ThirdParty {
+				ts.total++
+				if len(ci.HeaderComments) > 0 {
+					ts.documented++
+				}
+			}
+
+			continue
+		}
+
+		ps, ok := stats[key]
+		if !ok {
+			ps = &packageStats{
+				name:       res.Package.Name,
+				dir:        res.Package.Dir,
+				languages:  map[string]int{},
+				categories: map[string]*categoryStats{},
+			}
+			if m := res.Package.Module; m != nil {
+				ps.module = m.Path
+			}
+			stats[key] = ps
+			keys = append(keys, key)
+		}
+
+		if m := res.Package.Module; m != nil {
+			ms, ok := modules[m.Dir]
+			if !ok {
+				ms = &moduleStats{path: m.Path, dir: m.Dir}
+				modules[m.Dir] = ms
+				moduleKeys = append(moduleKeys, m.Dir)
+			}
+
+			for _, ci := range res.Items {
+				ms.total++
+				if len(ci.HeaderComments) > 0 {
+					ms.documented++
+				}
+			}
+		}
+
+		i.reportItems(res, ps)
+		i.reportDiagnostics(res.Diagnostics)
+		i.reportIgnored(res.Ignored)
+
+		items[key] = append(items[key], res.Items...)
+		all = append(all, res.Items...)
+		for _, ci := range res.Items {
+			owners[ci] = ps
+		}
+	}
+
+	for _, key := range keys {
+		i.reportPackage(stats[key])
+
+		for _, c := range ast.FindDuplicates(items[key]) {
+			i.reportDuplicate(c, "package", owners)
+		}
+	}
+
+	for _, dir := range moduleKeys {
+		i.reportModule(modules[dir])
+	}
+
+	for _, key := range thirdPartyKeys {
+		i.reportThirdParty(thirdParty[key])
+	}
+
+	// the clusters within a package are already reported above.
+	for _, c := range ast.FindDuplicates(all) {
+		for _, ci := range c.Items {
+			if owners[ci] != owners[c.Items[0]] {
+				i.reportDuplicate(c, "batch", owners)
+				break
+			}
+		}
+	}
+}
+
+// reportItems emits the Details of the CoverageItems and aggregates them into the packageStats.
+func (i *pluginImpl) reportItems(res *ast.Result, ps *packageStats) {
+	ps.command = res.Package.IsCommand()
+	ps.externalTest = res.Package.IsExternalTest()
+
+	for _, ci := range append(slices.Clone(res.Items), res.Separated...) {
+		category := res.Details[ci].Category
+		if _, ok := ps.categories[category]; !ok {
+			ps.categories[category] = &categoryStats{}
+		}
+
+		ps.categories[category].total++
+		if len(ci.HeaderComments) > 0 {
+			ps.categories[category].documented++
+		}
+	}
+
+	for _, ci := range res.Items {
+		d := res.Details[ci]
+
+		if ci.Scope == proto.CoverageItem_FILE && len(ci.HeaderComments) > 0 {
+			ps.usage = true
+		}
+
+		if d.Module != "" {
+			i.logger.Debug(
+				"module",
+				"file", ci.File,
+				"line", ci.TargetBlock.StartLine,
+				"identifier", ci.Identifier,
+				"documented", len(ci.HeaderComments) > 0,
+				"module", d.Module,
+			)
+		}
+
+		if d.Rule != "" {
+			i.logger.Debug(
+				"policy",
+				"file", ci.File,
+				"line", ci.TargetBlock.StartLine,
+				"identifier", ci.Identifier,
+				"kind", d.Kind,
+				"rule", d.Rule,
+			)
+		}
+
+		if d.Params != nil && d.Params.Total() > 0 {
+			i.logger.Info(
+				"parameter documentation coverage",
+				"file", ci.File,
+				"line", ci.TargetBlock.StartLine,
+				"identifier", ci.Identifier,
+				"mentioned", len(d.Params.Mentioned),
+				"total", d.Params.Total(),
+				"ratio", d.Params.Ratio(),
+				"missing", strings.Join(d.Params.Missing, ","),
+			)
+		}
+
+		if len(ci.HeaderComments) > 0 {
+			ps.documented++
+			if d.Metrics.IsOneLiner() {
+				ps.oneLiners++
+			}
+			ps.metrics.Add(d.Metrics)
+			ps.languages[d.Language]++
+
+			i.logger.Debug(
+				"doc metrics",
+				"file", ci.File,
+				"line", ci.TargetBlock.StartLine,
+				"identifier", ci.Identifier,
+				"module", d.Module,
+				"scope", ci.Scope.String(),
+				"paragraphs", d.Metrics.Paragraphs,
+				"words", d.Metrics.Words,
+				"code_blocks", d.Metrics.CodeBlocks,
+				"lists", d.Metrics.Lists,
+				"doc_links", d.Metrics.DocLinks,
+				"headings", d.Metrics.Headings,
+				"language", d.Language,
+			)
+		}
+
+		if d.Examples != nil && isPublic(ci.Scope) {
+			ps.exported++
+			if len(d.Examples) > 0 {
+				ps.withExample++
+			}
+
+			i.logger.Info(
+				"example",
+				"file", ci.File,
+				"line", ci.TargetBlock.StartLine,
+				"identifier", ci.Identifier,
+				"has_example", len(d.Examples) > 0,
+				"examples", strings.Join(d.Examples, ","),
+			)
+		}
+	}
+}
+
+// reportDiagnostics emits the Diagnostics.
+func (i *pluginImpl) reportDiagnostics(ds []*ast.Diagnostic) {
+	for _, d := range ds {
+		args := []interface{}{
+			"check", d.Check,
+			"file", d.File,
+			"line", d.Block.StartLine,
+			"column", d.Block.StartColumn,
+			"identifier", d.Identifier,
+		}
+		if d.Suggestion != "" {
+			args = append(args, "suggestion", d.Suggestion)
+		}
+
+		i.logger.Warn(d.Message, args...)
+	}
+}
+
+// reportIgnored emits the CoverageItems excluded by the ignore directives with their reasons, so that they can be audited.
+func (i *pluginImpl) reportIgnored(ignored []*ast.Ignored) {
+	for _, ig := range ignored {
+		i.logger.Info(
+			"ignored",
+			"file", ig.Item.File,
+			"line", ig.Item.TargetBlock.StartLine,
+			"identifier", ig.Item.Identifier,
+			"scope", ig.Item.Scope.String(),
+			"reason", ig.Reason,
+		)
+	}
+}
+
+// reportPackage emits the aggregated analysis results of the package.
+func (i *pluginImpl) reportPackage(ps *packageStats) {
+	if ps.documented > 0 {
+		i.logger.Info(
+			"doc metrics",
+			"package", ps.name,
+			"dir", ps.dir,
+			"module", ps.module,
+			"documented", ps.documented,
+			"one_liners", ps.oneLiners,
+			"average_words", float64(ps.metrics.Words)/float64(ps.documented),
+			"paragraphs", ps.metrics.Paragraphs,
+			"words", ps.metrics.Words,
+			"code_blocks", ps.metrics.CodeBlocks,
+			"lists", ps.metrics.Lists,
+			"doc_links", ps.metrics.DocLinks,
+			"headings", ps.metrics.Headings,
+		)
+
+		args := []interface{}{
+			"package", ps.name,
+			"dir", ps.dir,
+		}
+		labels := make([]string, 0, len(ps.languages))
+		for label := range ps.languages {
+			labels = append(labels, label)
+		}
+		sort.Strings(labels)
+		for _, label := range labels {
+			args = append(args, label, ps.languages[label])
+		}
+
+		i.logger.Info("languages", args...)
+	}
+
+	if ps.command {
+		i.logger.Info(
+			"command usage",
+			"package", ps.name,
+			"dir", ps.dir,
+			"documented", ps.usage,
+		)
+	}
+
+	for _, category := range []string{ast.CategoryTest, ast.CategoryTestHelper} {
+		c, ok := ps.categories[category]
+		if !ok {
+			continue
+		}
+
+		i.logger.Info(
+			"test coverage",
+			"package", ps.name,
+			"dir", ps.dir,
+			"external_test", ps.externalTest,
+			"category", category,
+			"documented", c.documented,
+			"total", c.total,
+			"ratio", float64(c.documented)/float64(c.total),
+		)
+	}
+
+	if ps.exported > 0 {
+		i.logger.Info(
+			"example coverage",
+			"package", ps.name,
+			"dir", ps.dir,
+			"with_example", ps.withExample,
+			"total", ps.exported,
+			"ratio", float64(ps.withExample)/float64(ps.exported),
+		)
+	}
+}
+
+// reportModule emits the coverage of the module, so that the modules in a workspace can be compared.
+func (i *pluginImpl) reportModule(ms *moduleStats) {
+	if ms.total == 0 {
+		return
+	}
+
+	i.logger.Info(
+		"module coverage",
+		"module", ms.path,
+		"dir", ms.dir,
+		"documented", ms.documented,
+		"total", ms.total,
+		"ratio", float64(ms.documented)/float64(ms.total),
+	)
+}
+
+// reportThirdParty emits the coverage of the package of the third-party code labeled by its origin.
+func (i *pluginImpl) reportThirdParty(ts *thirdPartyStats) {
+	if ts.total == 0 {
+		return
+	}
+
+	i.logger.Info(
+		"third party coverage",
+		"origin", ts.origin,
+		"package", ts.name,
+		"dir", ts.dir,
+		"documented", ts.documented,
+		"total", ts.total,
+		"ratio", float64(ts.documented)/float64(ts.total),
+	)
+}
+
+// reportDuplicate emits the cluster of the CoverageItems sharing the identical or near-identical header comments.
+// scope is either "package" or "batch".
+func (i *pluginImpl) reportDuplicate(c *ast.DuplicateCluster, scope string, owners map[*proto.CoverageItem]*packageStats) {
+	locations := make([]string, 0, len(c.Items))
+	for _, ci := range c.Items {
+		locations = append(locations, fmt.Sprintf("%s:%d:%s", ci.File, ci.TargetBlock.StartLine, ci.Identifier))
+	}
+
+	i.logger.Info(
+		"duplicate comments",
+		"scope", scope,
+		"package", owners[c.Items[0]].name,
+		"dir", owners[c.Items[0]].dir,
+		"exact", c.Exact,
+		"size", len(c.Items),
+		"comment", c.Key,
+		"items", strings.Join(locations, ","),
+	)
+}
+
+// isPublic returns true if the scope is a part of the public API, including the package itself.
+func isPublic(scope proto.CoverageItem_Scope) bool {
+	switch scope {
+	case proto.CoverageItem_FILE,
+		proto.CoverageItem_PUBLIC_MODULE,
+		proto.CoverageItem_PUBLIC_CLASS,
+		proto.CoverageItem_PUBLIC_TYPE,
+		proto.CoverageItem_PUBLIC_FUNCTION,
+		proto.CoverageItem_PUBLIC_VARIABLE:
+		return true
+
+	case proto.CoverageItem_UNKNOWN,
+		proto.CoverageItem_PRIVATE_MODULE,
+		proto.CoverageItem_PRIVATE_CLASS,
+		proto.CoverageItem_PRIVATE_TYPE,
+		proto.CoverageItem_PRIVATE_FUNCTION,
+		proto.CoverageItem_PRIVATE_VARIABLE:
+		return false
+	}
+
+	return false
+}